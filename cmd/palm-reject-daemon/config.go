@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/config"
+)
+
+// loadConfig reads the file named by --config and applies the flags the
+// user set explicitly on top of it, so flags always win over the file.
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	flags := cmd.Flags()
+	path, _ := flags.GetString("config")
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if userMode, _ := flags.GetBool("user"); userMode {
+		runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+		if runtimeDir == "" {
+			return nil, fmt.Errorf("--user needs XDG_RUNTIME_DIR to be set")
+		}
+		cfg.UseRuntimeDir(runtimeDir)
+	}
+	if flags.Changed("cooldown") {
+		d, _ := flags.GetDuration("cooldown")
+		cfg.Cooldown = config.Duration(d)
+	}
+	if flags.Changed("socket") {
+		cfg.SocketPath, _ = flags.GetString("socket")
+	}
+	if flags.Changed("metrics-listen") {
+		cfg.MetricsListen, _ = flags.GetString("metrics-listen")
+	}
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		cfg.Log.Level = level
+	}
+	if flags.Changed("log-level") {
+		cfg.Log.Level, _ = flags.GetString("log-level")
+	}
+	if flags.Changed("log-backend") {
+		cfg.Log.Backend, _ = flags.GetString("log-backend")
+	}
+	if flags.Changed("log-file") {
+		cfg.Log.File, _ = flags.GetString("log-file")
+	}
+	if flags.Changed("log-key-codes") {
+		cfg.Privacy.LogKeyCodes, _ = flags.GetBool("log-key-codes")
+	}
+
+	if flags.Changed("run-as") {
+		cfg.Privileges.RunAs, _ = flags.GetString("run-as")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
+)
+
+func runDevices(cmd *cobra.Command, _ []string) error {
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	devices, err := touchpad.ScanInputDevices()
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(devices)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DEVICE\tNAME\tID\tCAPABILITIES\tGROUP\tCLASS\tUSED\tRULE")
+	for _, d := range devices {
+		used := ""
+		if d.Selected {
+			used = "yes"
+		}
+		caps := make([]string, 0, len(d.Capabilities))
+		for _, c := range d.Capabilities {
+			caps = append(caps, strings.TrimPrefix(c, "EV_"))
+		}
+		group := "-"
+		if d.Group != "" {
+			group = filepath.Base(d.Group)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			filepath.Base(d.Path), d.Name, d.ID, strings.Join(caps, ","), group, d.Class, used, d.Rule)
+	}
+	return w.Flush()
+}
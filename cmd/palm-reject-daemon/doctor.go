@@ -0,0 +1,39 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/doctor"
+)
+
+func runDoctor(cmd *cobra.Command, _ []string) error {
+	asJSON, _ := cmd.Flags().GetBool("json")
+	skipGrab, _ := cmd.Flags().GetBool("skip-grab")
+	configPath, _ := cmd.Flags().GetString("config")
+
+	report := doctor.Run(doctor.Options{
+		ConfigPath: configPath,
+		SkipGrab:   skipGrab,
+		Logger:     zerolog.Nop(),
+	})
+
+	out := cmd.OutOrStdout()
+	var err error
+	if asJSON {
+		err = report.WriteJSON(out)
+	} else {
+		err = report.WriteText(out)
+	}
+	if err != nil {
+		return err
+	}
+
+	if report.Failed() {
+		cmd.SilenceUsage = true
+		return errors.New("doctor found problems")
+	}
+	return nil
+}
@@ -3,28 +3,48 @@ package main
 
 import (
     "context"
+    "errors"
+    "fmt"
     "os"
     "os/signal"
+    "os/user"
+    "strings"
     "syscall"
     "time"
 
+    "github.com/rs/zerolog"
     "github.com/spf13/cobra"
 
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/config"
     "github.com/artonio/zenbook-duo-palm-rejection/internal/consumer"
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/control"
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/dock"
     "github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/hidraw"
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/leds"
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/logind"
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/metrics"
     "github.com/artonio/zenbook-duo-palm-rejection/internal/pipe"
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/privsep"
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/profile"
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/systemd"
     "github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
     "github.com/artonio/zenbook-duo-palm-rejection/pkg/logging"
 )
 
 const version = "0.1.0"
 
+// controlSocketName is the FileDescriptorName= of the control socket in
+// palm-reject-daemon.socket.
+const controlSocketName = "control"
+
 func main() {
     rootCmd := &cobra.Command{
         Use:     "palm-reject-daemon",
         Short:   "Daemon that disables the touchpad while typing",
         Version: version,
     }
+    rootCmd.PersistentFlags().String("config", config.DefaultPath, "Configuration file")
 
     var timeout time.Duration
 
@@ -35,20 +55,99 @@ func main() {
     }
 
     runCmd.Flags().DurationVar(&timeout, "timeout", 0, "Auto-stop after duration (e.g., 10s, 1m) for safe testing")
+    runCmd.Flags().Duration("cooldown", 300*time.Millisecond, "How long the touchpad stays disabled after the last key press")
+    runCmd.Flags().String("socket", control.DefaultSocketPath, "Control socket path")
+    runCmd.Flags().String("metrics-listen", "", "Serve OpenMetrics on a TCP address (127.0.0.1:9477) or unix:/path; disabled if empty")
+    runCmd.Flags().String("log-level", "info", "Log level with optional per-component overrides, e.g. info,kb_monitor=warn (overrides LOG_LEVEL)")
+    runCmd.Flags().String("log-backend", logging.BackendAuto, "Log backend: "+strings.Join(logging.Backends, ", "))
+    runCmd.Flags().String("log-file", "", "Also write JSON logs to this file, rotated by size")
+    runCmd.Flags().Bool("log-key-codes", false, "Log raw key codes at debug level (default: key class and timing only)")
+    runCmd.Flags().String("run-as", "", "Drop root and run as this user once devices and sockets are open")
+    runCmd.Flags().Bool("user", false, "Run in the user's login session without root, opening devices through logind")
+
+    helperCmd := &cobra.Command{
+        Use:    privsep.HelperCommand,
+        Short:  "Open device nodes for the unprivileged daemon (started by run --run-as)",
+        Hidden: true,
+        Args:   cobra.NoArgs,
+        RunE: func(cmd *cobra.Command, _ []string) error {
+            cmd.SilenceUsage = true
+            return privsep.ServeHelper()
+        },
+    }
+
+    ctlCmd := &cobra.Command{
+        Use:   "ctl <command> [args...]",
+        Short: "Send a command to the running daemon (e.g. status, touchpad_toggle)",
+        Args:  cobra.MinimumNArgs(1),
+        RunE:  runCtl,
+    }
+    ctlCmd.Flags().String("socket", control.DefaultSocketPath, "Control socket path")
+    ctlCmd.Flags().Bool("user", false, "Talk to a daemon started with run --user")
+
+    doctorCmd := &cobra.Command{
+        Use:   "doctor",
+        Short: "Diagnose device discovery, permissions and configuration",
+        Args:  cobra.NoArgs,
+        RunE:  runDoctor,
+    }
+    doctorCmd.Flags().Bool("json", false, "Print the report as JSON")
+    doctorCmd.Flags().Bool("skip-grab", false, "Do not test grab/ungrab on the touchpads")
+
+    devicesCmd := &cobra.Command{
+        Use:   "devices",
+        Short: "List input devices and how discovery classifies them",
+        Args:  cobra.NoArgs,
+        RunE:  runDevices,
+    }
+    devicesCmd.Flags().Bool("json", false, "Print the device list as JSON")
 
-    rootCmd.AddCommand(runCmd)
+    monitorCmd := &cobra.Command{
+        Use:   "monitor",
+        Short: "Live view of keystrokes, touchpad contacts and suppression state",
+        Args:  cobra.NoArgs,
+        RunE:  runMonitor,
+    }
+    monitorCmd.Flags().Duration("cooldown", 300*time.Millisecond, "Cooldown to simulate")
+    monitorCmd.Flags().String("profile", "", "Profile from the config file to simulate")
+    monitorCmd.Flags().Bool("log-key-codes", false, "Show key names instead of key classes")
+
+    rootCmd.AddCommand(runCmd, ctlCmd, doctorCmd, devicesCmd, monitorCmd, helperCmd)
 
     if err := rootCmd.Execute(); err != nil {
+        if errors.Is(err, logind.ErrControlled) {
+            // Restarting cannot help; the user unit does not retry this
+            os.Exit(exitSessionControlled)
+        }
         os.Exit(1)
     }
 }
 
+// exitSessionControlled is the exit status of run --user in a session a
+// compositor controls (EX_CONFIG).
+const exitSessionControlled = 78
+
 func runDaemon(cmd *cobra.Command, _ []string) error {
     // Get timeout flag
     timeout, _ := cmd.Flags().GetDuration("timeout")
+
+    // Configuration. Errors before logging is configured go to the console
+    bootLogger := logging.SetupLogger(logging.GetLogLevelFromEnv())
+    cfg, err := loadConfig(cmd)
+    if err != nil {
+        bootLogger.Error().Err(err).Msg("failed to load configuration")
+        return err
+    }
+
     // Logging
-    logLevel := logging.GetLogLevelFromEnv()
-    logger := logging.SetupLogger(logLevel)
+    logOutput, err := logging.New(cfg.Log.Options())
+    if err != nil {
+        bootLogger.Error().Err(err).Msg("failed to set up logging")
+        return err
+    }
+    defer logOutput.Close()
+    logOutput.Levels.SetGlobal()
+    logger := logOutput.Logger
 
     logger.Info().
         Str("version", version).
@@ -57,9 +156,21 @@ func runDaemon(cmd *cobra.Command, _ []string) error {
     if timeout > 0 {
         logger.Warn().Dur("timeout", timeout).Msg("Running with timeout - will auto-stop")
     }
+    keyPolicy := cfg.Privacy.KeyPolicy()
+    if keyPolicy.RawCodes {
+        logger.Warn().Msg("log_key_codes is enabled: debug logs will contain what you type")
+    }
+
+    // Metrics (optional)
+    var stats *metrics.Metrics
+    metricsAddr := cfg.MetricsListen
+    if metricsAddr != "" {
+        stats = metrics.New()
+    }
 
     // Event bus
     systemEventBus := events.NewSystemEventBus(logger)
+    systemEventBus.SetMetrics(stats)
 
     // Context for graceful shutdown
     ctx, cancel := context.WithCancel(context.Background())
@@ -75,18 +186,64 @@ func runDaemon(cmd *cobra.Command, _ []string) error {
     }
     var components []component
 
+    // Who may use the pipe and control socket
+    pipePerm, err := cfg.Control.PipePermissions()
+    if err != nil {
+        logger.Error().Err(err).Msg("invalid control configuration")
+        return err
+    }
+    socketPerm, err := cfg.Control.SocketPermissions()
+    if err != nil {
+        logger.Error().Err(err).Msg("invalid control configuration")
+        return err
+    }
+    policy, err := cfg.Control.Policy()
+    if err != nil {
+        logger.Error().Err(err).Msg("invalid control configuration")
+        return err
+    }
+
     // Pipe receiver
     pipeReceiver := pipe.NewReceiver(
-        pipe.DefaultPipePath,
+        cfg.PipePath,
         systemEventBus,
         logger,
     )
+    pipeReceiver.SetMetrics(stats)
+    pipeReceiver.SetPermissions(pipePerm)
     if err := pipeReceiver.Start(ctx); err != nil {
         logger.Warn().Err(err).Msg("pipe receiver failed to start")
     } else {
         components = append(components, pipeReceiver)
     }
 
+    // Control server
+    controlServer := control.NewServer(cfg.SocketPath, systemEventBus, logger)
+    controlServer.AddStatus("version", func() any { return version })
+    controlServer.AddStatus("bus", func() any { return systemEventBus.Stats() })
+    controlServer.Handle("log_level", control.LogLevelHandler(logOutput.Levels))
+    controlServer.SetMetrics(stats)
+    controlServer.SetPermissions(socketPerm)
+    controlServer.SetPolicy(policy)
+    if err := startControlServer(ctx, controlServer, logger); err != nil {
+        logger.Warn().Err(err).Msg("control server failed to start")
+    } else {
+        components = append(components, controlServer)
+    }
+
+    if stats != nil {
+        metricsServer := metrics.NewServer(metricsAddr, stats, logger)
+        // Whoever may use the control socket may read the metrics
+        metricsServer.SetPermissions(socketPerm)
+        if err := metricsServer.Start(ctx); err != nil {
+            logger.Warn().Err(err).Msg("metrics server failed to start")
+        } else {
+            components = append(components, metricsServer)
+        }
+    }
+
+    notify(logger, systemd.Status("Discovering devices"))
+
     // Touchpad discovery
     devs, err := touchpad.FindAllTouchpadDevices(logger)
     if err != nil {
@@ -101,35 +258,93 @@ func runDaemon(cmd *cobra.Command, _ []string) error {
         return err
     }
 
+    // In a user session, devices the user may not open are taken from logind
+    var session *logind.Session
+    if userMode, _ := cmd.Flags().GetBool("user"); userMode {
+        if cfg.Privileges.RunAs != "" {
+            err := fmt.Errorf("privileges.run_as cannot be used with --user")
+            logger.Error().Err(err).Msg("invalid configuration")
+            return err
+        }
+        session, err = logind.Open(logger)
+        if errors.Is(err, logind.ErrControlled) {
+            logger.Error().Err(err).Msg("logind only hands input devices to the session's controller; " +
+                "run --user needs a session without a compositor, otherwise use the system service")
+            return err
+        }
+        if err != nil {
+            logger.Error().Err(err).Msg("failed to take control of the login session")
+            return err
+        }
+        privsep.SetHelper(session)
+    }
+
     // Touchpad controller
+    var typingConsumer *consumer.TypingDetectionConsumer
     touchpadCtrl := touchpad.NewMultiController(devs, logger)
+    touchpadCtrl.SetMetrics(stats)
+    if cfg.TouchUnlock.Enabled {
+        // Only reported while grabbed, which the consumer must have done
+        touchpadCtrl.SetOnIntent(cfg.TouchUnlock.Criteria(), func(path, reason string) {
+            typingConsumer.OnIntentionalTouch(path, reason)
+        })
+    }
+    touchpadCtrl.SetSuppression(cfg.Suppressions(), cfg.TapFilter.Criteria())
     if err := touchpadCtrl.Open(); err != nil {
         logger.Error().Err(err).Msg("failed to open touchpads")
         return err
     }
     components = append(components, touchpadCtrl)
 
+    // Typing only suppresses the touchpads on the keyboard's own device
+    refreshGroups := func() {
+        groups, err := touchpad.ScanDeviceGroups()
+        if err != nil {
+            logger.Warn().Err(err).Msg("failed to group input devices")
+            return
+        }
+        touchpadCtrl.SetGroups(groups)
+    }
+    refreshGroups()
+
     // Typing detection consumer
-    cooldown := 300 * time.Millisecond
-    typingConsumer := consumer.NewTypingDetectionConsumer(
+    cooldown := time.Duration(cfg.Cooldown)
+    typingConsumer = consumer.NewTypingDetectionConsumer(
         nil,            // will be set after monitor is created
         touchpadCtrl,
         systemEventBus,
         cooldown,
         logger,
     )
+    typingConsumer.SetMetrics(stats)
+
+    // Gaming mode: selected keys can be held while using the touchpad
+    exemptKeys, _ := touchpad.ParseKeys(cfg.Gaming.ExemptKeys) // validated with the config
+    chord, _ := touchpad.ParseKeys(cfg.Gaming.Chord)
+    toggleGaming := func() { systemEventBus.Publish(events.GamingModeToggle) }
+    gamingMode := consumer.NewGamingModeConsumer(exemptKeys, systemEventBus, logger)
+    if err := gamingMode.Start(ctx); err != nil {
+        logger.Error().Err(err).Msg("gaming mode failed to start")
+        return err
+    }
+    components = append(components, gamingMode)
+    controlServer.AddStatus("gaming", func() any { return gamingMode.Status() })
 
     // Keyboard monitor
     keyboardMonitor := touchpad.NewKeyboardMonitor(
         keyInfo.Path,
-        typingConsumer.OnKeyPress,
+        typingConsumer.OnKeyEvent,
         logger,
     )
+    keyboardMonitor.SetKeyPolicy(keyPolicy)
+    keyboardMonitor.SetKeyFilter(gamingMode)
+    keyboardMonitor.SetChord(chord, toggleGaming)
     if err := keyboardMonitor.Start(ctx); err != nil {
         logger.Error().Err(err).Msg("keyboard monitor failed to start")
         return err
     }
     components = append(components, keyboardMonitor)
+    controlServer.AddStatus("keyboard", func() any { return keyboardMonitor.Status() })
 
     // Start consumer
     if err := typingConsumer.Start(ctx); err != nil {
@@ -137,6 +352,122 @@ func runDaemon(cmd *cobra.Command, _ []string) error {
         return err
     }
     components = append(components, typingConsumer)
+    controlServer.AddStatus("touchpad", func() any { return typingConsumer.Status() })
+
+    // Profiles pick the settings for whoever is at the machine
+    profiles := profile.NewSelector(cfg.BaseSettings(), cfg.ProfileSettings(), cfg.Users, typingConsumer, logger)
+    profiles.SetApps(cfg.Apps)
+    controlServer.AddStatus("profile", func() any { return profiles.Status() })
+    controlServer.Handle("profile", control.ProfileHandler(profiles))
+
+    // ... and for the application in focus, in the compositor of whoever
+    // is at the seat
+    var sway *profile.Sway
+    if cfg.Focus.Provider != "" {
+        sway = profile.NewSway(cfg.Focus.Socket, logger)
+        var focus profile.FocusProvider = sway
+        focus.SetOnFocus(profiles.SetApp)
+        if err := focus.Start(ctx); err != nil {
+            logger.Warn().Err(err).Msg("not following window focus")
+        } else {
+            components = append(components, focus)
+        }
+    }
+    controlServer.Handle("watch", control.WatchHandler(typingConsumer))
+    controlServer.AddStatus("groups", func() any { return touchpadCtrl.Groups() })
+
+    // Dock detection: only suppress the touchpad that is with the keyboard
+    var dockPolicy *consumer.DockPolicyConsumer
+    if cfg.DockKeyboard != "" {
+        dockKeyboard, _ := dock.ParseUSBID(cfg.DockKeyboard) // validated with the config
+        dockPolicy = consumer.NewDockPolicyConsumer(touchpadCtrl, dockKeyboard, systemEventBus, logger)
+        // The keyboard moves between the USB and Bluetooth device groups
+        dockPolicy.SetOnChange(refreshGroups)
+        if err := dockPolicy.Start(ctx); err != nil {
+            logger.Warn().Err(err).Msg("dock policy failed to start")
+        } else {
+            components = append(components, dockPolicy)
+            controlServer.AddStatus("dock", func() any { return dockPolicy.Status() })
+
+            dockDetector := dock.NewDetector(dockKeyboard, dock.DefaultPollInterval, systemEventBus, logger)
+            if err := dockDetector.Start(ctx); err != nil {
+                logger.Warn().Err(err).Msg("dock detector failed to start")
+            } else {
+                components = append(components, dockDetector)
+            }
+        }
+    }
+
+    // Bluetooth keyboards come and go; follow them without a restart
+    bluetooth := touchpad.NewBluetoothManager(touchpadCtrl, typingConsumer.OnKeyEvent, func() {
+        refreshGroups()
+        if dockPolicy != nil {
+            dockPolicy.Refresh()
+        }
+    }, logger)
+    bluetooth.SetKeyPolicy(keyPolicy)
+    bluetooth.SetKeyFilter(gamingMode)
+    bluetooth.SetChord(chord, toggleGaming)
+    bluetoothStarted := false
+    if err := bluetooth.Start(ctx); err != nil {
+        logger.Warn().Err(err).Msg("bluetooth manager failed to start")
+    } else {
+        bluetoothStarted = true
+        components = append(components, bluetooth)
+        controlServer.AddStatus("bluetooth", func() any { return bluetooth.Pairs() })
+    }
+
+    // LED and display handlers (optional: missing hardware only disables them)
+    if micLeds, err := leds.Find(leds.MicMute); err != nil {
+        logger.Warn().Err(err).Msg("mic-mute LED handler disabled")
+    } else {
+        micMute := consumer.NewMicMuteConsumer(micLeds, systemEventBus, logger)
+        if err := micMute.Start(ctx); err != nil {
+            logger.Warn().Err(err).Msg("mic-mute LED handler failed to start")
+        } else {
+            components = append(components, micMute)
+            controlServer.AddStatus("micmute", func() any { return micMute.Status() })
+        }
+    }
+
+    if kbdLeds, err := leds.Find(leds.KbdBacklight); err != nil {
+        logger.Warn().Err(err).Msg("keyboard backlight handler disabled")
+    } else {
+        backlight := consumer.NewBacklightConsumer(kbdLeds, systemEventBus, logger)
+        if err := backlight.Start(ctx); err != nil {
+            logger.Warn().Err(err).Msg("keyboard backlight handler failed to start")
+        } else {
+            components = append(components, backlight)
+            controlServer.AddStatus("backlight", func() any { return backlight.Status() })
+        }
+    }
+
+    // Fn hotkeys (vendor HID reports, not evdev)
+    var hotkeyMonitors []*hidraw.Monitor
+    if fnDevs, err := hidraw.FindFnKeyDevices(); err != nil {
+        logger.Warn().Err(err).Msg("Fn hotkeys disabled")
+    } else {
+        for _, dev := range fnDevs {
+            hotkeys := hidraw.NewMonitor(dev, systemEventBus, logger)
+            if err := hotkeys.Start(ctx); err != nil {
+                logger.Warn().Err(err).Msg("hotkey monitor failed to start")
+                continue
+            }
+            components = append(components, hotkeys)
+            hotkeyMonitors = append(hotkeyMonitors, hotkeys)
+        }
+    }
+
+    if len(cfg.DisplayToggleCommand) == 0 {
+        logger.Info().Msg("no display_toggle_command configured; display toggle disabled")
+    } else {
+        display := consumer.NewDisplayConsumer(consumer.CommandAction{Argv: cfg.DisplayToggleCommand}, systemEventBus, logger)
+        if err := display.Start(ctx); err != nil {
+            logger.Warn().Err(err).Msg("display handler failed to start")
+        } else {
+            components = append(components, display)
+        }
+    }
 
     logger.Info().
         Strs("touchpads", getPaths(devs)).
@@ -145,6 +476,127 @@ func runDaemon(cmd *cobra.Command, _ []string) error {
         Int("touchpad_count", len(devs)).
         Msg("Palm rejection active")
 
+    if session != nil {
+        // logind revokes the devices while the session is inactive
+        session.SetOnChange(func(active bool) {
+            if !active {
+                typingConsumer.Pause(events.ReasonSession)
+                keyboardMonitor.Stop()
+                if bluetoothStarted {
+                    bluetooth.Stop()
+                }
+                for _, hotkeys := range hotkeyMonitors {
+                    hotkeys.Stop()
+                }
+                touchpadCtrl.Close()
+                session.ReleaseDevices()
+                return
+            }
+            if err := touchpadCtrl.Open(); err != nil {
+                logger.Error().Err(err).Msg("failed to reopen touchpads")
+            }
+            if err := keyboardMonitor.Start(ctx); err != nil {
+                logger.Error().Err(err).Msg("failed to reopen keyboard")
+            }
+            if bluetoothStarted {
+                if err := bluetooth.Start(ctx); err != nil {
+                    logger.Warn().Err(err).Msg("failed to restart bluetooth manager")
+                }
+            }
+            for _, hotkeys := range hotkeyMonitors {
+                if err := hotkeys.Start(ctx); err != nil {
+                    logger.Warn().Err(err).Msg("failed to reopen hotkey monitor")
+                }
+            }
+            typingConsumer.Resume()
+        })
+        if err := session.Start(ctx); err != nil {
+            logger.Warn().Err(err).Msg("not following the login session")
+        }
+        controlServer.AddStatus("session", func() any {
+            return map[string]any{"id": session.ID(), "active": session.Active()}
+        })
+    }
+
+    // The user session is always the user's; as root, follow the seat
+    if session != nil {
+        if u, err := user.Current(); err == nil {
+            profiles.SetUser(u.Username)
+        }
+    } else if cfg.Seat != "" {
+        seat, err := logind.OpenSeat(cfg.Seat, logger)
+        if err != nil {
+            logger.Warn().Err(err).Msg("not following the seat; palm rejection stays on for everyone")
+        } else {
+            onSeatChange := func(state logind.SeatState) {
+                if !state.Present() {
+                    // Nobody typing: greeter, lock screen or no session at all
+                    typingConsumer.Pause(events.ReasonSession)
+                    return
+                }
+                profiles.SetUser(state.User)
+                if sway != nil {
+                    sway.SetUID(state.UID)
+                }
+                typingConsumer.Resume()
+            }
+            seat.SetOnChange(onSeatChange)
+            onSeatChange(seat.State())
+            if err := seat.Start(ctx); err != nil {
+                logger.Warn().Err(err).Msg("not following the seat")
+                seat.Stop()
+            } else {
+                components = append(components, seat)
+                controlServer.AddStatus("seat", func() any { return seat.State() })
+            }
+        }
+    }
+
+    // Everything that needs root is open; give it up
+    stopAll := func() {
+        for _, c := range components {
+            if err := c.Stop(); err != nil {
+                logger.Warn().Err(err).Msg("failed to stop component")
+            }
+        }
+    }
+    if cfg.Privileges.RunAs != "" {
+        helper, err := dropPrivileges(cfg.Privileges, logOutput, logger)
+        if helper != nil {
+            // Stopped last so components can still open devices while stopping
+            components = append(components, helper)
+        }
+        if err != nil {
+            logger.Error().Err(err).Msg("failed to drop privileges")
+            stopAll()
+            return err
+        }
+        controlServer.AddStatus("privileges", func() any { return privilegeStatus() })
+    }
+    if session != nil {
+        // Stopped last so the other components close their devices first
+        components = append(components, session)
+    }
+
+    // Restart through systemd if the daemon stops handling typing or
+    // events; a detached or failed keyboard is no reason to restart
+    if interval, err := systemd.WatchdogInterval(); err != nil {
+        logger.Warn().Err(err).Msg("watchdog disabled")
+    } else if interval > 0 {
+        watchdog := systemd.NewWatchdog(interval, []systemd.HealthCheck{
+            {Name: "kb_monitor", Check: keyboardMonitor.Responsive},
+            {Name: "bus", Check: systemEventBus.Responsive},
+        }, logger)
+        if err := watchdog.Start(ctx); err != nil {
+            logger.Warn().Err(err).Msg("watchdog failed to start")
+        } else {
+            components = append(components, watchdog)
+            controlServer.AddStatus("watchdog", func() any { return watchdog.Status() })
+        }
+    }
+
+    notify(logger, systemd.StateReady, systemd.Status("Palm rejection active: %d touchpad(s), keyboard %s", len(devs), keyInfo.Path))
+
     // Wait for shutdown
     var sig os.Signal
     if timeout > 0 {
@@ -159,12 +611,10 @@ func runDaemon(cmd *cobra.Command, _ []string) error {
         logger.Info().Str("signal", sig.String()).Msg("Received shutdown signal")
     }
 
+    notify(logger, systemd.StateStopping, systemd.Status("Shutting down"))
+
     // Stop all components
-    for _, c := range components {
-        if err := c.Stop(); err != nil {
-            logger.Warn().Err(err).Msg("failed to stop component")
-        }
-    }
+    stopAll()
 
     systemEventBus.Close()
 
@@ -172,6 +622,95 @@ func runDaemon(cmd *cobra.Command, _ []string) error {
     return nil
 }
 
+func runCtl(cmd *cobra.Command, args []string) error {
+    cfg, err := loadConfig(cmd)
+    if err != nil {
+        return err
+    }
+
+    ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+    defer stop()
+
+    cmd.SilenceUsage = true
+    if err := control.Send(ctx, cfg.SocketPath, strings.Join(args, " "), cmd.OutOrStdout()); err != nil {
+        return fmt.Errorf("ctl %s: %w", args[0], err)
+    }
+    return nil
+}
+
+// startControlServer serves the control socket passed by systemd socket
+// activation (FileDescriptorName=control) if there is one, and creates the
+// socket itself otherwise.
+func startControlServer(ctx context.Context, server *control.Server, logger zerolog.Logger) error {
+    listeners, err := systemd.Listeners()
+    if err != nil {
+        return err
+    }
+    for name, l := range listeners {
+        if name != controlSocketName {
+            logger.Warn().Str("name", name).Msg("ignoring unknown socket passed by systemd")
+            l.Close()
+        }
+    }
+    if l, ok := listeners[controlSocketName]; ok {
+        return server.StartListener(ctx, l)
+    }
+    return server.Start(ctx)
+}
+
+// dropPrivileges starts the helper that opens device nodes on the daemon's
+// behalf and then switches to the configured user. The helper is returned
+// even on failure so the caller can stop it.
+func dropPrivileges(p config.Privileges, logOutput *logging.Output, logger zerolog.Logger) (*privsep.Client, error) {
+    creds, err := privsep.Lookup(p.RunAs, p.Groups)
+    if err != nil {
+        return nil, err
+    }
+    keep, err := privsep.ParseCapabilities(p.KeepCapabilities) // validated with the config
+    if err != nil {
+        return nil, err
+    }
+
+    helper, err := privsep.StartHelper()
+    if err != nil {
+        return nil, err
+    }
+    privsep.SetHelper(helper)
+
+    // Rotation creates files next to the log, which root owns so far
+    if err := logOutput.Chown(creds.UID, creds.GID); err != nil {
+        logger.Warn().Err(err).Msg("log file will not be rotated after dropping privileges")
+    }
+
+    if err := privsep.Drop(creds, keep); err != nil {
+        return helper, err
+    }
+    logger.Info().
+        Str("user", creds.User).
+        Int("uid", creds.UID).
+        Strs("groups", p.Groups).
+        Strs("capabilities", p.KeepCapabilities).
+        Msg("Dropped root privileges")
+    return helper, nil
+}
+
+// privilegeStatus reports the identity the daemon runs as.
+func privilegeStatus() map[string]any {
+    groups, _ := syscall.Getgroups()
+    return map[string]any{
+        "uid":    syscall.Getuid(),
+        "gid":    syscall.Getgid(),
+        "groups": groups,
+    }
+}
+
+// notify reports the daemon state to systemd; a no-op outside systemd.
+func notify(logger zerolog.Logger, states ...string) {
+    if err := systemd.Notify(states...); err != nil {
+        logger.Warn().Err(err).Msg("failed to notify systemd")
+    }
+}
+
 func getPaths(devs []*touchpad.DeviceInfo) []string {
     var paths []string
     for _, d := range devs {
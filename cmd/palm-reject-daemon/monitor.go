@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/monitor"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
+)
+
+func runMonitor(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Log output would tear up the screen, so discovery and the typing
+	// detection run silently.
+	logger := zerolog.Nop()
+
+	devs, err := touchpad.FindAllTouchpadDevices(logger)
+	if err != nil {
+		return err
+	}
+	keyInfo, err := touchpad.FindKeyboardDevice(logger)
+	if err != nil {
+		return err
+	}
+
+	// Simulate what the daemon would do, with the chosen profile if any
+	settings := cfg.BaseSettings()
+	if name, _ := cmd.Flags().GetString("profile"); name != "" {
+		profile, ok := cfg.ProfileSettings()[name]
+		if !ok {
+			return fmt.Errorf("unknown profile %q", name)
+		}
+		settings = profile
+		if cmd.Flags().Changed("cooldown") {
+			settings.Cooldown = time.Duration(cfg.Cooldown)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cmd.SilenceUsage = true
+	return monitor.Run(ctx, monitor.Options{
+		Keyboard:  keyInfo,
+		Touchpads: devs,
+		Settings:  settings,
+		Keys:      cfg.Privacy.KeyPolicy(),
+		Out:       cmd.OutOrStdout(),
+		Logger:    logger,
+	})
+}
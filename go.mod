@@ -3,6 +3,7 @@ module github.com/artonio/zenbook-duo-palm-rejection
 go 1.23.3
 
 require (
+	github.com/godbus/dbus/v5 v5.2.2
 	github.com/holoplot/go-evdev v0.0.0-20250804134636-ab1d56a1fe83
 	github.com/jonboulle/clockwork v0.5.0
 	github.com/rs/zerolog v1.34.0
@@ -10,6 +11,10 @@ require (
 	github.com/stretchr/testify v1.11.1
 )
 
+// NewFromFile, for descriptors passed by the privilege helper; see
+// third_party/go-evdev/README.md
+replace github.com/holoplot/go-evdev => ./third_party/go-evdev
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
@@ -18,6 +23,6 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
-	golang.org/x/sys v0.12.0 // indirect
+	golang.org/x/sys v0.27.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,538 @@
+// Package config loads the daemon configuration file.
+//
+// The file is JSON and every field is optional; missing fields keep their
+// defaults. Command line flags override values from the file.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/consumer"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/control"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/dock"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/endpoint"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/logind"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/pipe"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/privacy"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/privsep"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
+	"github.com/artonio/zenbook-duo-palm-rejection/pkg/logging"
+)
+
+// DefaultPath is where the daemon looks for its configuration file.
+const DefaultPath = "/etc/palm-reject/config.json"
+
+// Duration is a time.Duration that reads and writes strings like "300ms".
+type Duration time.Duration
+
+// UnmarshalJSON parses a Go duration string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"300ms\": %w", err)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalJSON writes the duration as a Go duration string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// FileMode is an os.FileMode that reads and writes octal strings like "0660".
+type FileMode os.FileMode
+
+// UnmarshalJSON parses an octal mode string.
+func (m *FileMode) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("mode must be an octal string like \"0660\": %w", err)
+	}
+	v, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || v > 0777 {
+		return fmt.Errorf("invalid mode %q", s)
+	}
+	*m = FileMode(v)
+	return nil
+}
+
+// MarshalJSON writes the mode as an octal string.
+func (m FileMode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("%04o", uint32(m)))
+}
+
+// Config is the daemon configuration.
+type Config struct {
+	// Cooldown is how long the touchpad stays disabled after the last key press.
+	Cooldown Duration `json:"cooldown"`
+	// PipePath is the named pipe accepting touchpad commands.
+	PipePath string `json:"pipe_path"`
+	// SocketPath is the control socket.
+	SocketPath string `json:"socket_path"`
+	// MetricsListen enables the OpenMetrics exporter when non-empty.
+	MetricsListen string `json:"metrics_listen,omitempty"`
+	// DisplayToggleCommand is run (argv form) to toggle the secondary display.
+	DisplayToggleCommand []string `json:"display_toggle_command,omitempty"`
+	// DockKeyboard is the USB vendor:product of the detachable keyboard used
+	// for dock detection; empty disables it.
+	DockKeyboard string `json:"dock_keyboard"`
+	// Log selects the logging backend and optional log file.
+	Log Log `json:"log"`
+	// Privacy controls what is recorded about key presses.
+	Privacy Privacy `json:"privacy"`
+	// Privileges controls dropping root after startup.
+	Privileges Privileges `json:"privileges"`
+	// Control controls who may use the pipe and control socket.
+	Control Control `json:"control"`
+	// Seat is the logind seat whose user the daemon follows: suppression
+	// pauses while nobody is logged in there or the screen is locked.
+	// Empty disables following.
+	Seat string `json:"seat"`
+	// Profiles are named sets of palm rejection settings.
+	Profiles map[string]Profile `json:"profiles,omitempty"`
+	// Users selects a profile by the name of the user at the seat.
+	Users map[string]string `json:"users,omitempty"`
+	// Apps selects a profile by the focused application, which wins over
+	// Users. Names are matched case-insensitively.
+	Apps map[string]string `json:"apps,omitempty"`
+	// Focus configures where the focused application comes from.
+	Focus Focus `json:"focus"`
+	// Gaming configures gaming mode.
+	Gaming Gaming `json:"gaming"`
+	// TouchUnlock ends a typing suppression when the touchpad is used
+	// deliberately.
+	TouchUnlock TouchUnlock `json:"touch_unlock"`
+	// Touchpads configures individual touchpads, keyed by device name (as
+	// listed by "devices") or event node path; "*" applies to the rest.
+	Touchpads map[string]Touchpad `json:"touchpads,omitempty"`
+	// TapFilter decides what the "taps" suppression drops.
+	TapFilter TapFilter `json:"tap_filter"`
+}
+
+// Touchpad is an entry of the "touchpads" section.
+type Touchpad struct {
+	// Suppression is what the touchpad still lets through while typing:
+	// "grab" (nothing, the default), "clicks" (physical button clicks) or
+	// "taps" (everything but taps).
+	Suppression string `json:"suppression,omitempty"`
+}
+
+// TapFilter is the "tap_filter" section.
+type TapFilter struct {
+	// A contact lifted within Time that travelled less than MoveMM is a tap.
+	Time   Duration `json:"time"`
+	MoveMM float64  `json:"move_mm"`
+}
+
+// Criteria converts the section to touchpad tap criteria.
+func (t TapFilter) Criteria() touchpad.TapCriteria {
+	return touchpad.TapCriteria{Time: time.Duration(t.Time), MoveMM: t.MoveMM}
+}
+
+// Suppressions returns the suppression mode of every configured touchpad.
+func (c *Config) Suppressions() map[string]touchpad.Suppression {
+	modes := make(map[string]touchpad.Suppression, len(c.Touchpads))
+	for name, t := range c.Touchpads {
+		// Validate has rejected unknown modes
+		modes[name], _ = touchpad.ParseSuppression(t.Suppression)
+	}
+	return modes
+}
+
+// TouchUnlock is the "touch_unlock" section.
+type TouchUnlock struct {
+	Enabled bool `json:"enabled"`
+	// Fingers down at once that count as deliberate; 0 disables.
+	Fingers int `json:"fingers"`
+	// Click counts a physical click as deliberate.
+	Click bool `json:"click"`
+	// MoveMM of travel by one finger kept down for MoveTime counts as
+	// deliberate; 0 disables.
+	MoveMM   float64  `json:"move_mm"`
+	MoveTime Duration `json:"move_time"`
+}
+
+// Criteria converts the section to touchpad intent criteria.
+func (t TouchUnlock) Criteria() touchpad.IntentCriteria {
+	return touchpad.IntentCriteria{
+		Fingers:  t.Fingers,
+		Click:    t.Click,
+		MoveMM:   t.MoveMM,
+		MoveTime: time.Duration(t.MoveTime),
+	}
+}
+
+// Gaming is the "gaming" section.
+type Gaming struct {
+	// ExemptKeys do not count as typing while gaming mode is on.
+	ExemptKeys []string `json:"exempt_keys"`
+	// Chord toggles gaming mode when all its keys are held; empty disables
+	// the hotkey.
+	Chord []string `json:"chord"`
+}
+
+// Focus is the "focus" section.
+type Focus struct {
+	// Provider is "sway" or "i3"; empty disables application profiles.
+	Provider string `json:"provider,omitempty"`
+	// Socket is the compositor's IPC socket; found automatically if empty.
+	Socket string `json:"socket,omitempty"`
+}
+
+// Profile is an entry of the "profiles" section.
+type Profile struct {
+	// Cooldown replaces the global cooldown when set.
+	Cooldown Duration `json:"cooldown,omitempty"`
+	// Off turns palm rejection off.
+	Off bool `json:"off,omitempty"`
+}
+
+// BaseSettings are the typing detection settings without a profile.
+func (c *Config) BaseSettings() consumer.Settings {
+	return consumer.Settings{Cooldown: time.Duration(c.Cooldown)}
+}
+
+// ProfileSettings resolves every profile against the global settings.
+func (c *Config) ProfileSettings() map[string]consumer.Settings {
+	settings := make(map[string]consumer.Settings, len(c.Profiles))
+	for name, p := range c.Profiles {
+		s := c.BaseSettings()
+		if p.Cooldown != 0 {
+			s.Cooldown = time.Duration(p.Cooldown)
+		}
+		s.Off = p.Off
+		settings[name] = s
+	}
+	return settings
+}
+
+// Control is the "control" section of the configuration.
+type Control struct {
+	// Owner and Group own the pipe and socket; empty keeps the user and
+	// group the daemon was started as.
+	Owner string `json:"owner,omitempty"`
+	Group string `json:"group,omitempty"`
+	// SocketMode and PipeMode are the endpoints' permissions.
+	SocketMode FileMode `json:"socket_mode"`
+	PipeMode   FileMode `json:"pipe_mode"`
+	// Allow restricts control socket commands to users and groups, keyed
+	// by command name or "*" for all other commands. An empty entry leaves
+	// the command to root and the daemon's own user.
+	Allow map[string]Principals `json:"allow,omitempty"`
+}
+
+// Principals are the users and groups (names or numeric IDs) allowed to
+// run a command.
+type Principals struct {
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// SocketPermissions resolves the control socket's owner, group and mode.
+func (c Control) SocketPermissions() (endpoint.Permissions, error) {
+	return c.permissions(c.SocketMode)
+}
+
+// PipePermissions resolves the pipe's owner, group and mode.
+func (c Control) PipePermissions() (endpoint.Permissions, error) {
+	return c.permissions(c.PipeMode)
+}
+
+func (c Control) permissions(mode FileMode) (endpoint.Permissions, error) {
+	perm := endpoint.Permissions{UID: -1, GID: -1, Mode: os.FileMode(mode)}
+	var err error
+	if c.Owner != "" {
+		if perm.UID, err = lookupUID(c.Owner); err != nil {
+			return endpoint.Permissions{}, fmt.Errorf("control.owner: %w", err)
+		}
+	}
+	if c.Group != "" {
+		if perm.GID, err = lookupGID(c.Group); err != nil {
+			return endpoint.Permissions{}, fmt.Errorf("control.group: %w", err)
+		}
+	}
+	return perm, nil
+}
+
+// Policy resolves the allow-list; nil if there is none.
+func (c Control) Policy() (control.Policy, error) {
+	if len(c.Allow) == 0 {
+		return nil, nil
+	}
+	policy := make(control.Policy, len(c.Allow))
+	var errs []error
+	for name, p := range c.Allow {
+		var rule control.Rule
+		for _, u := range p.Users {
+			uid, err := lookupUID(u)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("control.allow.%s: %w", name, err))
+				continue
+			}
+			rule.UIDs = append(rule.UIDs, uid)
+		}
+		for _, g := range p.Groups {
+			gid, err := lookupGID(g)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("control.allow.%s: %w", name, err))
+				continue
+			}
+			rule.GIDs = append(rule.GIDs, gid)
+		}
+		policy[strings.ToLower(name)] = rule
+	}
+	return policy, errors.Join(errs...)
+}
+
+// lookupUID resolves a user name or numeric UID.
+func lookupUID(name string) (int, error) {
+	if uid, err := strconv.Atoi(name); err == nil {
+		return uid, nil
+	}
+	u, err := user.Lookup(name)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(u.Uid)
+}
+
+// lookupGID resolves a group name or numeric GID.
+func lookupGID(name string) (int, error) {
+	if gid, err := strconv.Atoi(name); err == nil {
+		return gid, nil
+	}
+	g, err := user.LookupGroup(name)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(g.Gid)
+}
+
+// Privileges is the "privileges" section of the configuration.
+type Privileges struct {
+	// RunAs is the user the daemon switches to once its devices and
+	// sockets are open; empty keeps the user it was started as.
+	RunAs string `json:"run_as,omitempty"`
+	// Groups are the supplementary groups kept after switching.
+	Groups []string `json:"groups"`
+	// KeepCapabilities are retained after switching, e.g. "CAP_DAC_OVERRIDE".
+	KeepCapabilities []string `json:"keep_capabilities,omitempty"`
+}
+
+// Privacy is the "privacy" section of the configuration.
+type Privacy struct {
+	// LogKeyCodes records raw key codes in debug logs and the monitor
+	// instead of only the key class. Off unless explicitly enabled.
+	LogKeyCodes bool `json:"log_key_codes"`
+}
+
+// KeyPolicy converts the section to a key privacy policy.
+func (p Privacy) KeyPolicy() privacy.KeyPolicy {
+	return privacy.KeyPolicy{RawCodes: p.LogKeyCodes}
+}
+
+// Log is the "log" section of the configuration.
+type Log struct {
+	// Level is the minimum level with optional per-component overrides,
+	// e.g. "info,kb_monitor=warn"; the LOG_LEVEL environment variable and
+	// --log-level take precedence.
+	Level string `json:"level"`
+	// Backend is auto, console, json or journald.
+	Backend string `json:"backend"`
+	// File, if set, receives a JSON copy of the log.
+	File string `json:"file,omitempty"`
+	// FileMaxSizeMB is the size at which File is rotated.
+	FileMaxSizeMB int `json:"file_max_size_mb"`
+	// FileMaxBackups is how many rotated files are kept.
+	FileMaxBackups int `json:"file_max_backups"`
+}
+
+// Options converts the section to logging options.
+func (l Log) Options() logging.Options {
+	return logging.Options{
+		Level:          l.Level,
+		Backend:        l.Backend,
+		File:           l.File,
+		FileMaxSize:    int64(l.FileMaxSizeMB) << 20,
+		FileMaxBackups: l.FileMaxBackups,
+	}
+}
+
+// Default returns the built-in configuration.
+func Default() *Config {
+	return &Config{
+		Cooldown:     Duration(300 * time.Millisecond),
+		PipePath:     pipe.DefaultPipePath,
+		SocketPath:   control.DefaultSocketPath,
+		DockKeyboard: dock.DefaultKeyboard,
+		Privileges:   Privileges{Groups: []string{"input"}},
+		Control:      Control{SocketMode: 0660, PipeMode: 0620},
+		Seat:         logind.DefaultSeat,
+		TouchUnlock: TouchUnlock{
+			Enabled:  true,
+			Fingers:  2,
+			Click:    true,
+			MoveMM:   10,
+			MoveTime: Duration(150 * time.Millisecond),
+		},
+		TapFilter: TapFilter{
+			Time:   Duration(touchpad.DefaultTapCriteria.Time),
+			MoveMM: touchpad.DefaultTapCriteria.MoveMM,
+		},
+		Gaming: Gaming{
+			ExemptKeys: []string{"KEY_W", "KEY_A", "KEY_S", "KEY_D", "KEY_Q", "KEY_E", "KEY_R", "KEY_F", "KEY_SPACE", "KEY_LEFTSHIFT", "KEY_LEFTCTRL", "KEY_TAB"},
+			Chord:      []string{"KEY_LEFTCTRL", "KEY_LEFTALT", "KEY_G"},
+		},
+		Log: Log{
+			Level:          "info",
+			Backend:        logging.BackendAuto,
+			FileMaxSizeMB:  logging.DefaultMaxSize >> 20,
+			FileMaxBackups: 3,
+		},
+	}
+}
+
+// Load reads the configuration file at path on top of the defaults.
+// A missing file is only an error when path is not DefaultPath.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path == "" {
+		path = DefaultPath
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && path == DefaultPath {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// UseRuntimeDir moves the pipe and socket that are still at their default
+// location into dir/palm-reject, for a daemon running as a user service
+// with dir being XDG_RUNTIME_DIR.
+func (c *Config) UseRuntimeDir(dir string) {
+	if c.PipePath == pipe.DefaultPipePath {
+		c.PipePath = filepath.Join(dir, "palm-reject", filepath.Base(pipe.DefaultPipePath))
+	}
+	if c.SocketPath == control.DefaultSocketPath {
+		c.SocketPath = filepath.Join(dir, "palm-reject", filepath.Base(control.DefaultSocketPath))
+	}
+}
+
+// Validate reports every invalid value in the configuration.
+func (c *Config) Validate() error {
+	var errs []error
+	if d := time.Duration(c.Cooldown); d < 50*time.Millisecond || d > 10*time.Second {
+		errs = append(errs, fmt.Errorf("cooldown %s out of range [50ms, 10s]", d))
+	}
+	if !filepath.IsAbs(c.PipePath) {
+		errs = append(errs, fmt.Errorf("pipe_path %q must be absolute", c.PipePath))
+	}
+	if !filepath.IsAbs(c.SocketPath) {
+		errs = append(errs, fmt.Errorf("socket_path %q must be absolute", c.SocketPath))
+	}
+	if c.PipePath == c.SocketPath {
+		errs = append(errs, fmt.Errorf("pipe_path and socket_path must differ"))
+	}
+	if len(c.DisplayToggleCommand) > 0 && c.DisplayToggleCommand[0] == "" {
+		errs = append(errs, fmt.Errorf("display_toggle_command must start with a program name"))
+	}
+	if c.DockKeyboard != "" {
+		if _, err := dock.ParseUSBID(c.DockKeyboard); err != nil {
+			errs = append(errs, fmt.Errorf("dock_keyboard: %w", err))
+		}
+	}
+	if c.Control.SocketMode&0002 != 0 {
+		errs = append(errs, fmt.Errorf("control.socket_mode %04o must not be writable by other users", uint32(c.Control.SocketMode)))
+	}
+	if c.Control.PipeMode&0002 != 0 {
+		errs = append(errs, fmt.Errorf("control.pipe_mode %04o must not be writable by other users", uint32(c.Control.PipeMode)))
+	}
+	if _, ok := c.Control.Allow[""]; ok {
+		errs = append(errs, fmt.Errorf("control.allow has an empty command name"))
+	}
+	for name, p := range c.Profiles {
+		if d := time.Duration(p.Cooldown); d != 0 && (d < 50*time.Millisecond || d > 10*time.Second) {
+			errs = append(errs, fmt.Errorf("profiles.%s.cooldown %s out of range [50ms, 10s]", name, d))
+		}
+	}
+	for user, name := range c.Users {
+		if _, ok := c.Profiles[name]; !ok {
+			errs = append(errs, fmt.Errorf("users.%s refers to unknown profile %q", user, name))
+		}
+	}
+	for app, name := range c.Apps {
+		if _, ok := c.Profiles[name]; !ok {
+			errs = append(errs, fmt.Errorf("apps.%s refers to unknown profile %q", app, name))
+		}
+	}
+	if c.TouchUnlock.Fingers < 0 || c.TouchUnlock.Fingers > 5 {
+		errs = append(errs, fmt.Errorf("touch_unlock.fingers %d out of range [0, 5]", c.TouchUnlock.Fingers))
+	}
+	if c.TouchUnlock.MoveMM < 0 || c.TouchUnlock.MoveTime < 0 {
+		errs = append(errs, errors.New("touch_unlock.move_mm and move_time must not be negative"))
+	}
+	for name, t := range c.Touchpads {
+		if _, err := touchpad.ParseSuppression(t.Suppression); err != nil {
+			errs = append(errs, fmt.Errorf("touchpads.%s.suppression: %w", name, err))
+		}
+	}
+	if c.TapFilter.Time <= 0 || c.TapFilter.MoveMM <= 0 {
+		errs = append(errs, errors.New("tap_filter.time and move_mm must be positive"))
+	}
+	if _, err := touchpad.ParseKeys(c.Gaming.ExemptKeys); err != nil {
+		errs = append(errs, fmt.Errorf("gaming.exempt_keys: %w", err))
+	}
+	if _, err := touchpad.ParseKeys(c.Gaming.Chord); err != nil {
+		errs = append(errs, fmt.Errorf("gaming.chord: %w", err))
+	}
+	if p := c.Focus.Provider; p != "" && p != "sway" && p != "i3" {
+		errs = append(errs, fmt.Errorf(`focus.provider %q must be "sway" or "i3"`, p))
+	}
+	if c.Focus.Socket != "" && !filepath.IsAbs(c.Focus.Socket) {
+		errs = append(errs, fmt.Errorf("focus.socket %q must be absolute", c.Focus.Socket))
+	}
+	if _, err := privsep.ParseCapabilities(c.Privileges.KeepCapabilities); err != nil {
+		errs = append(errs, fmt.Errorf("privileges.keep_capabilities: %w", err))
+	}
+	if _, err := logging.ParseLevels(c.Log.Level); err != nil {
+		errs = append(errs, fmt.Errorf("log.level: %w", err))
+	}
+	if !slices.Contains(logging.Backends, c.Log.Backend) {
+		errs = append(errs, fmt.Errorf("log.backend %q must be one of %s", c.Log.Backend, strings.Join(logging.Backends, ", ")))
+	}
+	if c.Log.File != "" && !filepath.IsAbs(c.Log.File) {
+		errs = append(errs, fmt.Errorf("log.file %q must be absolute", c.Log.File))
+	}
+	if c.Log.FileMaxSizeMB < 1 {
+		errs = append(errs, fmt.Errorf("log.file_max_size_mb must be at least 1"))
+	}
+	if c.Log.FileMaxBackups < 0 {
+		errs = append(errs, fmt.Errorf("log.file_max_backups must not be negative"))
+	}
+	return errors.Join(errs...)
+}
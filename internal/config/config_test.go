@@ -0,0 +1,131 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/consumer"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/control"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/endpoint"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeConfig(t, `{"cooldown": "500ms", "metrics_listen": "127.0.0.1:9477"}`)
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, Duration(500*time.Millisecond), cfg.Cooldown)
+	assert.Equal(t, "127.0.0.1:9477", cfg.MetricsListen)
+	assert.Equal(t, Default().PipePath, cfg.PipePath)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoad_Errors(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to read config")
+
+	_, err = Load(writeConfig(t, `{"cooldwn": "1s"}`))
+	assert.ErrorContains(t, err, "unknown field")
+
+	_, err = Load(writeConfig(t, `{"cooldown": 300}`))
+	assert.ErrorContains(t, err, "duration must be a string")
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Default()
+	cfg.Cooldown = Duration(time.Millisecond)
+	cfg.PipePath = "relative.pipe"
+	cfg.DisplayToggleCommand = []string{"", "--toggle"}
+	cfg.DockKeyboard = "0b05"
+	cfg.Log.Level = "info,kb_monitor=quiet"
+	cfg.Log.Backend = "syslog"
+	cfg.Log.File = "daemon.log"
+	cfg.Privileges.KeepCapabilities = []string{"CAP_SETUID"}
+	cfg.Control.PipeMode = 0622
+	cfg.Gaming.Chord = []string{"KEY_LEFTCTRL", "KEY_HYPER"}
+	cfg.TouchUnlock.Fingers = 6
+	cfg.Touchpads = map[string]Touchpad{"ELAN1200:00 Touchpad": {Suppression: "palms"}}
+	cfg.TapFilter.MoveMM = 0
+
+	err := cfg.Validate()
+	assert.ErrorContains(t, err, "cooldown 1ms out of range")
+	assert.ErrorContains(t, err, `pipe_path "relative.pipe" must be absolute`)
+	assert.ErrorContains(t, err, "display_toggle_command must start with a program name")
+	assert.ErrorContains(t, err, `dock_keyboard: invalid USB ID "0b05"`)
+	assert.ErrorContains(t, err, `log.level: kb_monitor: unknown log level "quiet"`)
+	assert.ErrorContains(t, err, `log.backend "syslog" must be one of`)
+	assert.ErrorContains(t, err, `log.file "daemon.log" must be absolute`)
+	assert.ErrorContains(t, err, "privileges.keep_capabilities: CAP_SETUID would allow regaining root")
+	assert.ErrorContains(t, err, "control.pipe_mode 0622 must not be writable by other users")
+	assert.ErrorContains(t, err, `gaming.chord: unknown key "KEY_HYPER"`)
+	assert.ErrorContains(t, err, "touch_unlock.fingers 6 out of range [0, 5]")
+	assert.ErrorContains(t, err, `touchpads.ELAN1200:00 Touchpad.suppression: unknown suppression "palms"`)
+	assert.ErrorContains(t, err, "tap_filter.time and move_mm must be positive")
+}
+
+func TestUseRuntimeDir(t *testing.T) {
+	cfg := Default()
+	cfg.SocketPath = "/run/custom.sock"
+	cfg.UseRuntimeDir("/run/user/1000")
+	assert.Equal(t, "/run/user/1000/palm-reject/daemon.pipe", cfg.PipePath)
+	assert.Equal(t, "/run/custom.sock", cfg.SocketPath, "explicit paths are kept")
+}
+
+func TestControl(t *testing.T) {
+	path := writeConfig(t, `{"control": {"group": "0", "pipe_mode": "0660", "allow": {"Log_Level": {"users": ["root", "1000"]}, "*": {}}}}`)
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	perm, err := cfg.Control.PipePermissions()
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Permissions{UID: -1, GID: 0, Mode: 0660}, perm)
+
+	policy, err := cfg.Control.Policy()
+	require.NoError(t, err)
+	assert.Equal(t, control.Policy{"log_level": {UIDs: []int{0, 1000}}, "*": {}}, policy)
+
+	_, err = Load(writeConfig(t, `{"control": {"socket_mode": "rw"}}`))
+	assert.ErrorContains(t, err, `invalid mode "rw"`)
+
+	cfg.Control.Allow = map[string]Principals{"status": {Groups: []string{"no-such-group-here"}}}
+	_, err = cfg.Control.Policy()
+	assert.ErrorContains(t, err, "control.allow.status")
+}
+
+func TestProfiles(t *testing.T) {
+	path := writeConfig(t, `{"cooldown": "400ms", "profiles": {"drawing": {"cooldown": "1s"}, "off": {"off": true}}, "users": {"alice": "drawing"}}`)
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, "seat0", cfg.Seat)
+
+	assert.Equal(t, consumer.Settings{Cooldown: 400 * time.Millisecond}, cfg.BaseSettings())
+	assert.Equal(t, map[string]consumer.Settings{
+		"drawing": {Cooldown: time.Second},
+		"off":     {Cooldown: 400 * time.Millisecond, Off: true},
+	}, cfg.ProfileSettings())
+
+	cfg.Profiles["drawing"] = Profile{Cooldown: Duration(time.Minute)}
+	cfg.Users["bob"] = "gaming"
+	cfg.Apps = map[string]string{"krita": "painting"}
+	cfg.Focus = Focus{Provider: "hyprland", Socket: "sway.sock"}
+	err = cfg.Validate()
+	assert.ErrorContains(t, err, "profiles.drawing.cooldown 1m0s out of range")
+	assert.ErrorContains(t, err, `users.bob refers to unknown profile "gaming"`)
+	assert.ErrorContains(t, err, `apps.krita refers to unknown profile "painting"`)
+	assert.ErrorContains(t, err, `focus.provider "hyprland" must be "sway" or "i3"`)
+	assert.ErrorContains(t, err, `focus.socket "sway.sock" must be absolute`)
+}
@@ -0,0 +1,90 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+)
+
+// displayActionTimeout bounds how long a display toggle may run.
+const displayActionTimeout = 10 * time.Second
+
+// DisplayAction switches the secondary (lower) display on or off. How that
+// is done depends on the compositor, so it is pluggable.
+type DisplayAction interface {
+	ToggleSecondaryDisplay(ctx context.Context) error
+}
+
+// CommandAction toggles the display by running an external command, e.g.
+// a script calling wlr-randr or gnome-randr.
+type CommandAction struct {
+	Argv []string
+}
+
+// ToggleSecondaryDisplay runs the command and waits for it to finish.
+func (a CommandAction) ToggleSecondaryDisplay(ctx context.Context) error {
+	if len(a.Argv) == 0 {
+		return fmt.Errorf("no display toggle command configured")
+	}
+	out, err := exec.CommandContext(ctx, a.Argv[0], a.Argv[1:]...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("display toggle command %q failed: %w: %s", a.Argv[0], err, out)
+	}
+	return nil
+}
+
+// DisplayConsumer runs a DisplayAction on SecondaryDisplayToggle events.
+type DisplayConsumer struct {
+	ctx            context.Context
+	cancel         context.CancelFunc
+	action         DisplayAction
+	systemEventBus *events.SystemEventBus
+	logger         zerolog.Logger
+	done           chan struct{}
+}
+
+// NewDisplayConsumer creates a consumer running action on each toggle.
+func NewDisplayConsumer(action DisplayAction, systemEventBus *events.SystemEventBus, logger zerolog.Logger) *DisplayConsumer {
+	return &DisplayConsumer{
+		action:         action,
+		systemEventBus: systemEventBus,
+		logger:         logger.With().Str("component", "display").Logger(),
+	}
+}
+
+// Start subscribes to the event bus.
+func (c *DisplayConsumer) Start(ctx context.Context) error {
+	c.ctx, c.cancel = context.WithCancel(ctx)
+	sub := c.systemEventBus.Subscribe(events.WithName("display"))
+	c.done = runEventLoop(c.ctx, sub, c.handleSystemEvent)
+	c.logger.Info().Msg("Display consumer started")
+	return nil
+}
+
+// Stop stops the consumer, cancelling a toggle that is still running.
+func (c *DisplayConsumer) Stop() error {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+	c.logger.Info().Msg("Display consumer stopped")
+	return nil
+}
+
+func (c *DisplayConsumer) handleSystemEvent(event events.SystemEvent) {
+	if event != events.SecondaryDisplayToggle {
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, displayActionTimeout)
+	defer cancel()
+	if err := c.action.ToggleSecondaryDisplay(ctx); err != nil {
+		c.logger.Error().Err(err).Msg("Failed to toggle secondary display")
+		return
+	}
+	c.logger.Info().Msg("Secondary display toggled")
+}
@@ -0,0 +1,144 @@
+package consumer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/dock"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
+)
+
+// TouchpadSelector limits palm rejection to a subset of touchpads.
+// MultiController implements it.
+type TouchpadSelector interface {
+	SetActive(paths []string)
+	ActivePaths() []string
+}
+
+// DockPolicyConsumer applies palm rejection only to the touchpad that is
+// physically together with the detachable keyboard, following the dock
+// state published by dock.Detector.
+type DockPolicyConsumer struct {
+	ctx            context.Context
+	cancel         context.CancelFunc
+	selector       TouchpadSelector
+	keyboard       dock.USBID
+	systemEventBus *events.SystemEventBus
+	logger         zerolog.Logger
+	done           chan struct{}
+	// scan lists the input devices; replaced in tests
+	scan func() ([]*touchpad.InputDevice, error)
+	// onChange runs when the keyboard docks or undocks
+	onChange func()
+
+	mu     sync.Mutex
+	docked bool
+	known  bool
+}
+
+// NewDockPolicyConsumer creates the dock policy for the keyboard's USB ID.
+func NewDockPolicyConsumer(selector TouchpadSelector, keyboard dock.USBID, systemEventBus *events.SystemEventBus, logger zerolog.Logger) *DockPolicyConsumer {
+	return &DockPolicyConsumer{
+		selector:       selector,
+		keyboard:       keyboard,
+		systemEventBus: systemEventBus,
+		logger:         logger.With().Str("component", "dock_policy").Logger(),
+		scan:           touchpad.ScanInputDevices,
+	}
+}
+
+// Start subscribes to the event bus. Start it before the dock detector so
+// the initial state is not missed.
+func (c *DockPolicyConsumer) Start(ctx context.Context) error {
+	c.ctx, c.cancel = context.WithCancel(ctx)
+	sub := c.systemEventBus.Subscribe(
+		events.WithName("dock_policy"),
+		events.WithPolicy(events.CoalesceLatest),
+	)
+	c.done = runEventLoop(c.ctx, sub, c.handleSystemEvent)
+	c.logger.Info().Msg("Dock policy started")
+	return nil
+}
+
+// SetOnChange sets a function called when the keyboard docks or undocks,
+// before the policy is applied, e.g. to regroup the input devices. Call
+// before Start.
+func (c *DockPolicyConsumer) SetOnChange(fn func()) {
+	c.onChange = fn
+}
+
+// Stop stops the consumer.
+func (c *DockPolicyConsumer) Stop() error {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+	c.logger.Info().Msg("Dock policy stopped")
+	return nil
+}
+
+func (c *DockPolicyConsumer) handleSystemEvent(event events.SystemEvent) {
+	var docked bool
+	switch event {
+	case events.USBKeyboardAttached:
+		docked = true
+	case events.USBKeyboardDetached:
+		docked = false
+	default:
+		return
+	}
+	if c.onChange != nil {
+		c.onChange()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.docked, c.known = docked, true
+	c.applyLocked()
+}
+
+// Refresh re-applies the policy to the current devices, e.g. after a
+// Bluetooth keyboard and its touchpad connected.
+func (c *DockPolicyConsumer) Refresh() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.known {
+		c.applyLocked()
+	}
+}
+
+func (c *DockPolicyConsumer) applyLocked() {
+	docked := c.docked
+	devices, err := c.scan()
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to scan input devices")
+		return
+	}
+	paths := dock.PairedTouchpads(docked, c.keyboard, devices)
+	c.selector.SetActive(paths)
+	c.logger.Info().
+		Bool("docked", docked).
+		Strs("touchpads", c.selector.ActivePaths()).
+		Msg("Palm rejection follows keyboard")
+}
+
+// DockStatus is reported by the control interface.
+type DockStatus struct {
+	Docked    *bool    `json:"docked"`
+	Touchpads []string `json:"touchpads"`
+}
+
+// Status returns the dock state and the touchpads palm rejection applies to.
+func (c *DockPolicyConsumer) Status() DockStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := DockStatus{Touchpads: c.selector.ActivePaths()}
+	if c.known {
+		docked := c.docked
+		s.Docked = &docked
+	}
+	return s
+}
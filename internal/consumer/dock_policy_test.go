@@ -0,0 +1,93 @@
+package consumer
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/dock"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
+)
+
+type fakeSelector struct {
+	mu     sync.Mutex
+	active []string
+}
+
+func (f *fakeSelector) SetActive(paths []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.active = paths
+}
+
+func (f *fakeSelector) ActivePaths() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.active
+}
+
+func TestDockPolicyConsumer(t *testing.T) {
+	bus := events.NewSystemEventBus(zerolog.Nop())
+	defer bus.Close()
+
+	selector := &fakeSelector{}
+	c := NewDockPolicyConsumer(selector, dock.USBID{Vendor: 0x0b05, Product: 0x1b2c}, bus, zerolog.Nop())
+	usbPad := &touchpad.InputDevice{
+		Path:           "/dev/input/event7",
+		ID:             touchpad.InputID{BusType: uint16(evdev.BUS_USB), Vendor: 0x0b05, Product: 0x1b2c},
+		Classification: touchpad.Classification{Class: touchpad.ClassTouchpad},
+	}
+	btPad := &touchpad.InputDevice{
+		Path:           "/dev/input/event12",
+		ID:             touchpad.InputID{BusType: uint16(evdev.BUS_BLUETOOTH), Vendor: 0x0b05, Product: 0x1b2d},
+		Classification: touchpad.Classification{Class: touchpad.ClassTouchpad},
+	}
+	var mu sync.Mutex
+	devices := []*touchpad.InputDevice{usbPad, btPad}
+	c.scan = func() ([]*touchpad.InputDevice, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return devices, nil
+	}
+	var changes atomic.Int32
+	c.SetOnChange(func() { changes.Add(1) })
+	require.NoError(t, c.Start(context.Background()))
+	defer c.Stop()
+
+	assert.Nil(t, c.Status().Docked, "unknown until the detector reports")
+
+	bus.Publish(events.USBKeyboardAttached)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"/dev/input/event7"}, selector.ActivePaths())
+	}, time.Second, 5*time.Millisecond)
+	require.NotNil(t, c.Status().Docked)
+	assert.True(t, *c.Status().Docked)
+
+	bus.Publish(events.USBKeyboardDetached)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"/dev/input/event12"}, selector.ActivePaths())
+	}, time.Second, 5*time.Millisecond)
+	assert.False(t, *c.Status().Docked)
+	assert.Equal(t, int32(2), changes.Load(), "groups refreshed on every dock change")
+
+	// The Bluetooth keyboard disconnects, then comes back
+	mu.Lock()
+	devices = []*touchpad.InputDevice{usbPad}
+	mu.Unlock()
+	c.Refresh()
+	assert.Equal(t, []string{}, selector.ActivePaths())
+
+	mu.Lock()
+	devices = []*touchpad.InputDevice{usbPad, btPad}
+	mu.Unlock()
+	c.Refresh()
+	assert.Equal(t, []string{"/dev/input/event12"}, selector.ActivePaths())
+}
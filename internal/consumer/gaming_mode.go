@@ -0,0 +1,109 @@
+package consumer
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	evdev "github.com/holoplot/go-evdev"
+	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+)
+
+// GamingModeConsumer switches gaming mode on the gaming_on, gaming_off and
+// gaming_toggle commands. While it is on, the exempt keys (WASD and the
+// like) can be held while using the touchpad: the keyboard monitors, which
+// use it as their touchpad.KeyFilter, do not report them as typing.
+type GamingModeConsumer struct {
+	ctx            context.Context
+	cancel         context.CancelFunc
+	exempt         map[evdev.EvCode]bool
+	systemEventBus *events.SystemEventBus
+	logger         zerolog.Logger
+	done           chan struct{}
+
+	mu sync.Mutex
+	on bool
+}
+
+// NewGamingModeConsumer creates gaming mode, off, for the exempt keys.
+func NewGamingModeConsumer(exempt []evdev.EvCode, systemEventBus *events.SystemEventBus, logger zerolog.Logger) *GamingModeConsumer {
+	c := &GamingModeConsumer{
+		exempt:         make(map[evdev.EvCode]bool, len(exempt)),
+		systemEventBus: systemEventBus,
+		logger:         logger.With().Str("component", "gaming_mode").Logger(),
+	}
+	for _, code := range exempt {
+		c.exempt[code] = true
+	}
+	return c
+}
+
+// Start subscribes to the event bus.
+func (c *GamingModeConsumer) Start(ctx context.Context) error {
+	c.ctx, c.cancel = context.WithCancel(ctx)
+	sub := c.systemEventBus.Subscribe(
+		events.WithName("gaming_mode"),
+		events.WithPolicy(events.CoalesceLatest),
+	)
+	c.done = runEventLoop(c.ctx, sub, c.handleSystemEvent)
+	c.logger.Info().Int("exempt_keys", len(c.exempt)).Msg("Gaming mode ready")
+	return nil
+}
+
+// Stop stops the consumer.
+func (c *GamingModeConsumer) Stop() error {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+	c.logger.Info().Msg("Gaming mode stopped")
+	return nil
+}
+
+func (c *GamingModeConsumer) handleSystemEvent(event events.SystemEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	on := c.on
+	switch event {
+	case events.GamingModeOn:
+		on = true
+	case events.GamingModeOff:
+		on = false
+	case events.GamingModeToggle:
+		on = !on
+	default:
+		return
+	}
+	if on != c.on {
+		c.on = on
+		c.logger.Info().Bool("on", on).Msg("Gaming mode changed")
+	}
+}
+
+// Exempt reports whether a press of code is not typing: gaming mode is on
+// and code is one of its keys. It implements touchpad.KeyFilter.
+func (c *GamingModeConsumer) Exempt(code evdev.EvCode) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.on && c.exempt[code]
+}
+
+// GamingStatus is reported by the control interface.
+type GamingStatus struct {
+	On         bool     `json:"on"`
+	ExemptKeys []string `json:"exempt_keys"`
+}
+
+// Status returns whether gaming mode is on and which keys it exempts.
+func (c *GamingModeConsumer) Status() GamingStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := GamingStatus{On: c.on, ExemptKeys: make([]string, 0, len(c.exempt))}
+	for code := range c.exempt {
+		s.ExemptKeys = append(s.ExemptKeys, evdev.CodeName(evdev.EV_KEY, code))
+	}
+	sort.Strings(s.ExemptKeys)
+	return s
+}
@@ -0,0 +1,33 @@
+package consumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+)
+
+func TestGamingModeConsumer(t *testing.T) {
+	bus := events.NewSystemEventBus(zerolog.Nop())
+	defer bus.Close()
+
+	c := NewGamingModeConsumer([]evdev.EvCode{evdev.KEY_W, evdev.KEY_A}, bus, zerolog.Nop())
+	require.NoError(t, c.Start(context.Background()))
+	defer c.Stop()
+
+	assert.False(t, c.Exempt(evdev.KEY_W), "gaming mode starts off")
+	assert.Equal(t, GamingStatus{ExemptKeys: []string{"KEY_A", "KEY_W"}}, c.Status())
+
+	bus.Publish(events.GamingModeToggle)
+	assert.Eventually(t, func() bool { return c.Exempt(evdev.KEY_W) }, time.Second, 5*time.Millisecond)
+	assert.False(t, c.Exempt(evdev.KEY_E), "other keys still count as typing")
+
+	bus.Publish(events.GamingModeOff)
+	assert.Eventually(t, func() bool { return !c.Status().On }, time.Second, 5*time.Millisecond)
+}
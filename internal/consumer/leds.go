@@ -0,0 +1,277 @@
+package consumer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/leds"
+)
+
+// backlightLevels is the number of keyboard backlight steps (off, low,
+// medium, high). LEDs with a finer max_brightness are scaled.
+const backlightLevels = 4
+
+// MicMuteConsumer drives the mic-mute LED from MicMuteLed* events.
+type MicMuteConsumer struct {
+	ctx            context.Context
+	cancel         context.CancelFunc
+	leds           []*leds.LED
+	systemEventBus *events.SystemEventBus
+	logger         zerolog.Logger
+	done           chan struct{}
+
+	mu sync.Mutex
+}
+
+// NewMicMuteConsumer creates a consumer driving the given mic-mute LEDs.
+func NewMicMuteConsumer(micLeds []*leds.LED, systemEventBus *events.SystemEventBus, logger zerolog.Logger) *MicMuteConsumer {
+	return &MicMuteConsumer{
+		leds:           micLeds,
+		systemEventBus: systemEventBus,
+		logger:         logger.With().Str("component", "micmute").Logger(),
+	}
+}
+
+// Start subscribes to the event bus.
+func (c *MicMuteConsumer) Start(ctx context.Context) error {
+	c.ctx, c.cancel = context.WithCancel(ctx)
+	sub := c.systemEventBus.Subscribe(
+		events.WithName("micmute"),
+		events.WithPolicy(events.CoalesceLatest),
+	)
+	c.done = runEventLoop(c.ctx, sub, c.handleSystemEvent)
+	c.logger.Info().Strs("leds", ledNames(c.leds)).Msg("Mic-mute LED consumer started")
+	return nil
+}
+
+// Stop stops the consumer.
+func (c *MicMuteConsumer) Stop() error {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+	c.logger.Info().Msg("Mic-mute LED consumer stopped")
+	return nil
+}
+
+func (c *MicMuteConsumer) handleSystemEvent(event events.SystemEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var on bool
+	switch event {
+	case events.MicMuteLedOn:
+		on = true
+	case events.MicMuteLedOff:
+		on = false
+	case events.MicMuteLedToggle:
+		on = !c.mutedLocked()
+	default:
+		return
+	}
+
+	for _, led := range c.leds {
+		value := 0
+		if on {
+			limit, err := led.MaxBrightness()
+			if err != nil {
+				c.logger.Error().Err(err).Msg("Failed to read mic-mute LED")
+				continue
+			}
+			value = limit
+		}
+		if err := led.SetBrightness(value); err != nil {
+			c.logger.Error().Err(err).Msg("Failed to set mic-mute LED")
+			continue
+		}
+	}
+	c.logger.Debug().Bool("on", on).Msg("Mic-mute LED updated")
+}
+
+// mutedLocked reports whether the first LED is lit.
+func (c *MicMuteConsumer) mutedLocked() bool {
+	if len(c.leds) == 0 {
+		return false
+	}
+	b, err := c.leds[0].Brightness()
+	if err != nil {
+		c.logger.Warn().Err(err).Msg("Failed to read mic-mute LED")
+		return false
+	}
+	return b > 0
+}
+
+// MicMuteStatus is reported by the control interface.
+type MicMuteStatus struct {
+	On   bool     `json:"on"`
+	LEDs []string `json:"leds"`
+}
+
+// Status returns the current LED state.
+func (c *MicMuteConsumer) Status() MicMuteStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return MicMuteStatus{On: c.mutedLocked(), LEDs: ledNames(c.leds)}
+}
+
+// BacklightConsumer sets the keyboard backlight from Backlight* events.
+type BacklightConsumer struct {
+	ctx            context.Context
+	cancel         context.CancelFunc
+	leds           []*leds.LED
+	systemEventBus *events.SystemEventBus
+	logger         zerolog.Logger
+	done           chan struct{}
+
+	mu sync.Mutex
+}
+
+// NewBacklightConsumer creates a consumer driving the given keyboard backlight LEDs.
+func NewBacklightConsumer(kbdLeds []*leds.LED, systemEventBus *events.SystemEventBus, logger zerolog.Logger) *BacklightConsumer {
+	return &BacklightConsumer{
+		leds:           kbdLeds,
+		systemEventBus: systemEventBus,
+		logger:         logger.With().Str("component", "backlight").Logger(),
+	}
+}
+
+// Start subscribes to the event bus.
+func (c *BacklightConsumer) Start(ctx context.Context) error {
+	c.ctx, c.cancel = context.WithCancel(ctx)
+	sub := c.systemEventBus.Subscribe(
+		events.WithName("backlight"),
+		events.WithPolicy(events.CoalesceLatest),
+	)
+	c.done = runEventLoop(c.ctx, sub, c.handleSystemEvent)
+	c.logger.Info().Strs("leds", ledNames(c.leds)).Msg("Keyboard backlight consumer started")
+	return nil
+}
+
+// Stop stops the consumer.
+func (c *BacklightConsumer) Stop() error {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+	c.logger.Info().Msg("Keyboard backlight consumer stopped")
+	return nil
+}
+
+func (c *BacklightConsumer) handleSystemEvent(event events.SystemEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var level int
+	switch event {
+	case events.BacklightOff:
+		level = 0
+	case events.BacklightLow:
+		level = 1
+	case events.BacklightMedium:
+		level = 2
+	case events.BacklightHigh:
+		level = 3
+	case events.BacklightToggle:
+		// Cycle off -> low -> medium -> high -> off like the Fn key does
+		current, top := c.levelLocked()
+		level = (current + 1) % (top + 1)
+	default:
+		return
+	}
+
+	for _, led := range c.leds {
+		limit, err := led.MaxBrightness()
+		if err != nil {
+			c.logger.Error().Err(err).Msg("Failed to read keyboard backlight")
+			continue
+		}
+		if err := led.SetBrightness(levelToBrightness(level, limit)); err != nil {
+			c.logger.Error().Err(err).Msg("Failed to set keyboard backlight")
+		}
+	}
+	c.logger.Debug().Int("level", level).Msg("Keyboard backlight updated")
+}
+
+// levelLocked returns the level of the first LED and the highest level it supports.
+func (c *BacklightConsumer) levelLocked() (level, top int) {
+	top = backlightLevels - 1
+	if len(c.leds) == 0 {
+		return 0, top
+	}
+	limit, err := c.leds[0].MaxBrightness()
+	if err != nil {
+		c.logger.Warn().Err(err).Msg("Failed to read keyboard backlight")
+		return 0, top
+	}
+	b, err := c.leds[0].Brightness()
+	if err != nil {
+		c.logger.Warn().Err(err).Msg("Failed to read keyboard backlight")
+		return 0, top
+	}
+	return brightnessToLevel(b, limit), min(limit, top)
+}
+
+// BacklightStatus is reported by the control interface.
+type BacklightStatus struct {
+	Level int      `json:"level"`
+	LEDs  []string `json:"leds"`
+}
+
+// Status returns the current backlight level (0-3).
+func (c *BacklightConsumer) Status() BacklightStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	level, _ := c.levelLocked()
+	return BacklightStatus{Level: level, LEDs: ledNames(c.leds)}
+}
+
+// levelToBrightness scales a backlight level to an LED's brightness range.
+// LEDs with fewer steps than backlightLevels use their raw brightness values.
+func levelToBrightness(level, limit int) int {
+	steps := backlightLevels - 1
+	if limit < steps {
+		return min(level, limit)
+	}
+	return (level*limit + steps/2) / steps
+}
+
+// brightnessToLevel maps a brightness back to the nearest backlight level.
+func brightnessToLevel(brightness, limit int) int {
+	steps := backlightLevels - 1
+	if limit < steps {
+		return brightness
+	}
+	return (brightness*steps + limit/2) / limit
+}
+
+// runEventLoop delivers events from sub to handle until ctx is cancelled or
+// the bus closes. The returned channel is closed when the loop exits.
+func runEventLoop(ctx context.Context, sub <-chan events.SystemEvent, handle func(events.SystemEvent)) chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-sub:
+				if !ok {
+					return
+				}
+				handle(event)
+			}
+		}
+	}()
+	return done
+}
+
+func ledNames(list []*leds.LED) []string {
+	names := make([]string, 0, len(list))
+	for _, led := range list {
+		names = append(names, led.Name)
+	}
+	return names
+}
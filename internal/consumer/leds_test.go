@@ -0,0 +1,168 @@
+package consumer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/leds"
+)
+
+// fakeLEDTree creates a sysfs LED class tree with one LED and returns it.
+func fakeLEDTree(t *testing.T, name string, max int) *leds.LED {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, name)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "brightness"), []byte("0\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "max_brightness"), []byte(strconv.Itoa(max)+"\n"), 0644))
+
+	function := name[strings.LastIndex(name, "::")+2:]
+	found, err := leds.FindIn(root, function)
+	require.NoError(t, err)
+	return found[0]
+}
+
+func brightnessEventually(t *testing.T, led *leds.LED, want int) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		b, err := led.Brightness()
+		return err == nil && b == want
+	}, time.Second, 5*time.Millisecond, "brightness of %s should become %d", led.Name, want)
+}
+
+func TestMicMuteConsumer(t *testing.T) {
+	led := fakeLEDTree(t, "platform::micmute", 1)
+	bus := events.NewSystemEventBus(zerolog.Nop())
+	defer bus.Close()
+
+	c := NewMicMuteConsumer([]*leds.LED{led}, bus, zerolog.Nop())
+	require.NoError(t, c.Start(context.Background()))
+	defer c.Stop()
+
+	bus.Publish(events.MicMuteLedOn)
+	brightnessEventually(t, led, 1)
+	assert.True(t, c.Status().On)
+
+	bus.Publish(events.MicMuteLedToggle)
+	brightnessEventually(t, led, 0)
+
+	bus.Publish(events.MicMuteLedToggle)
+	brightnessEventually(t, led, 1)
+
+	bus.Publish(events.MicMuteLedOff)
+	brightnessEventually(t, led, 0)
+	assert.False(t, c.Status().On)
+}
+
+func TestBacklightConsumer(t *testing.T) {
+	led := fakeLEDTree(t, "asus::kbd_backlight", 3)
+	bus := events.NewSystemEventBus(zerolog.Nop())
+	defer bus.Close()
+
+	c := NewBacklightConsumer([]*leds.LED{led}, bus, zerolog.Nop())
+	require.NoError(t, c.Start(context.Background()))
+	defer c.Stop()
+
+	bus.Publish(events.BacklightMedium)
+	brightnessEventually(t, led, 2)
+	assert.Equal(t, 2, c.Status().Level)
+
+	// Toggle cycles medium -> high -> off -> low
+	for _, want := range []int{3, 0, 1} {
+		bus.Publish(events.BacklightToggle)
+		brightnessEventually(t, led, want)
+	}
+
+	bus.Publish(events.BacklightOff)
+	brightnessEventually(t, led, 0)
+}
+
+func TestBacklightConsumer_ToggleOnCoarseLED(t *testing.T) {
+	led := fakeLEDTree(t, "asus::kbd_backlight", 1)
+	bus := events.NewSystemEventBus(zerolog.Nop())
+	defer bus.Close()
+
+	c := NewBacklightConsumer([]*leds.LED{led}, bus, zerolog.Nop())
+	require.NoError(t, c.Start(context.Background()))
+	defer c.Stop()
+
+	// An on/off backlight cycles between its only two states
+	for _, want := range []int{1, 0, 1} {
+		bus.Publish(events.BacklightToggle)
+		brightnessEventually(t, led, want)
+	}
+}
+
+func TestBacklightLevelScaling(t *testing.T) {
+	tests := []struct {
+		level, limit, brightness int
+	}{
+		{0, 3, 0},
+		{2, 3, 2},
+		{1, 255, 85},
+		{2, 255, 170},
+		{3, 255, 255},
+		{1, 2, 1},
+		{2, 2, 2},
+		{1, 1, 1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.brightness, levelToBrightness(tt.level, tt.limit), "level %d of %d", tt.level, tt.limit)
+		assert.Equal(t, tt.level, brightnessToLevel(tt.brightness, tt.limit), "brightness %d of %d", tt.brightness, tt.limit)
+	}
+}
+
+type fakeDisplayAction struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (f *fakeDisplayAction) ToggleSecondaryDisplay(context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return nil
+}
+
+func (f *fakeDisplayAction) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func TestDisplayConsumer(t *testing.T) {
+	bus := events.NewSystemEventBus(zerolog.Nop())
+	defer bus.Close()
+
+	action := &fakeDisplayAction{}
+	c := NewDisplayConsumer(action, bus, zerolog.Nop())
+	require.NoError(t, c.Start(context.Background()))
+	defer c.Stop()
+
+	bus.Publish(events.MicMuteLedToggle)
+	bus.Publish(events.SecondaryDisplayToggle)
+	assert.Eventually(t, func() bool { return action.Calls() == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestCommandAction(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "toggled")
+	action := CommandAction{Argv: []string{"touch", out}}
+	require.NoError(t, action.ToggleSecondaryDisplay(context.Background()))
+	assert.FileExists(t, out)
+
+	err := CommandAction{Argv: []string{"false"}}.ToggleSecondaryDisplay(context.Background())
+	assert.ErrorContains(t, err, `display toggle command "false" failed`)
+
+	assert.Error(t, CommandAction{}.ToggleSecondaryDisplay(context.Background()))
+}
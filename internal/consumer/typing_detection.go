@@ -8,9 +8,31 @@ import (
     "github.com/rs/zerolog"
 
     "github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/metrics"
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/privacy"
     "github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
 )
 
+// Settings are the typing detection parameters a profile may change at
+// runtime.
+type Settings struct {
+    // Cooldown is how long the touchpad stays disabled after the last key press.
+    Cooldown time.Duration
+    // Off turns palm rejection off; typing no longer suppresses the touchpad.
+    Off bool
+}
+
+// maxKeyHold is how long a key may be held before its release is assumed
+// lost (a dropped event or a keyboard gone mid-hold) and it stops keeping
+// the touchpad suppressed.
+const maxKeyHold = 30 * time.Second
+
+// heldKey identifies a key on a keyboard.
+type heldKey struct {
+    keyboard string
+    code     uint16
+}
+
 // TypingDetectionConsumer disables the touchpad while typing to prevent accidental cursor movement (palm rejection).
 type TypingDetectionConsumer struct {
     ctx             context.Context
@@ -18,13 +40,21 @@ type TypingDetectionConsumer struct {
     keyboardMonitor *touchpad.KeyboardMonitor
     touchpadCtrl    touchpad.TouchpadController
     systemEventBus  *events.SystemEventBus
+    stateFeed       *events.StateFeed
     cooldown        time.Duration
     logger          zerolog.Logger
+    metrics         *metrics.Metrics
 
-    mu           sync.Mutex
-    lastKeyPress time.Time
-    timer        *time.Timer
-    isDisabled   bool
+    mu             sync.Mutex
+    lastKeyPress   time.Time
+    timer          *time.Timer
+    isDisabled     bool
+    disabledAt     time.Time
+    disabledReason events.SuppressionReason
+    paused         bool
+    off            bool
+    // held are the typing keys physically down and when they were pressed
+    held map[heldKey]time.Time
 }
 
 // NewTypingDetectionConsumer creates a new typing detection consumer.
@@ -35,12 +65,15 @@ func NewTypingDetectionConsumer(
     cooldown time.Duration,
     logger zerolog.Logger,
 ) *TypingDetectionConsumer {
+    logger = logger.With().Str("component", "typing_detection").Logger()
     return &TypingDetectionConsumer{
         keyboardMonitor: keyboardMonitor,
         touchpadCtrl:    touchpadCtrl,
         systemEventBus:  systemEventBus,
+        stateFeed:       events.NewStateFeed(logger),
         cooldown:        cooldown,
-        logger:          logger.With().Str("component", "typing_detection").Logger(),
+        logger:          logger,
+        held:            make(map[heldKey]time.Time),
     }
 }
 
@@ -68,37 +101,95 @@ func (c *TypingDetectionConsumer) Stop() error {
     defer c.mu.Unlock()
 
     // Stop the cooldown timer
-    if c.timer != nil {
-        c.timer.Stop()
-        c.timer = nil
-    }
+    c.stopTimerLocked()
 
     // Ensure touchpad is enabled on shutdown
     if c.isDisabled {
-        if err := c.touchpadCtrl.Enable(); err != nil {
+        if err := c.enableLocked(events.ReasonShutdown); err != nil {
             c.logger.Warn().Err(err).Msg("Failed to enable touchpad during shutdown")
+            c.markEnabledLocked(events.ReasonShutdown)
         }
-        c.isDisabled = false
     }
 
+    c.stateFeed.Close()
     c.logger.Info().Msg("Typing detection consumer stopped")
     return nil
 }
 
 // OnKeyPress is called when a key is pressed on the keyboard.
 func (c *TypingDetectionConsumer) OnKeyPress() {
+    c.OnKeyPressFrom("")
+}
+
+// OnKeyPressFrom is called when a key is pressed on the keyboard at the
+// given device path. If the touchpad controller can route by keyboard,
+// only the touchpads on the same physical device are suppressed.
+func (c *TypingDetectionConsumer) OnKeyPressFrom(keyboard string) {
     c.mu.Lock()
     defer c.mu.Unlock()
 
+    if c.paused || c.off {
+        return
+    }
+    c.keyPressLocked(keyboard)
+}
+
+// OnKeyEvent handles a key event from a keyboard monitor. A press counts
+// like OnKeyPressFrom; in addition the touchpad stays suppressed for as
+// long as any typing key is held, and the cooldown starts over when the
+// last one is released. Modifiers are not tracked, so holding Ctrl or
+// Shift for a touchpad gesture or click does not keep it suppressed.
+func (c *TypingDetectionConsumer) OnKeyEvent(ev touchpad.KeyEvent) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    if c.paused || c.off {
+        return
+    }
+    key := heldKey{keyboard: ev.Keyboard, code: uint16(ev.Code)}
+    switch ev.Action {
+    case touchpad.KeyPress:
+        if privacy.Classify(ev.Code) != privacy.ClassModifier {
+            c.held[key] = time.Now()
+        }
+        c.keyPressLocked(ev.Keyboard)
+
+    case touchpad.KeyRelease:
+        if _, ok := c.held[key]; !ok {
+            return
+        }
+        delete(c.held, key)
+        if len(c.held) == 0 && c.isDisabled && c.disabledReason == events.ReasonTyping {
+            c.lastKeyPress = time.Now()
+            c.stopTimerLocked()
+            c.timer = time.AfterFunc(c.cooldown, c.onCooldownExpired)
+        }
+    }
+    // Repeats change nothing: the key is still held
+}
+
+// keyPressLocked suppresses the touchpad and restarts the cooldown.
+// Callers must hold c.mu.
+func (c *TypingDetectionConsumer) keyPressLocked(keyboard string) {
     c.lastKeyPress = time.Now()
+    c.metrics.ObserveKeystroke()
 
-    // Disable touchpad if not already disabled
-    if !c.isDisabled {
-        if err := c.touchpadCtrl.Disable(); err != nil {
+    if router, ok := c.touchpadCtrl.(touchpad.KeyboardRouter); ok && keyboard != "" {
+        // Typing on another keyboard may add touchpads, so always route
+        if err := router.DisableFor(keyboard); err != nil {
+            c.logger.Error().Err(err).Str("keyboard", keyboard).Msg("Failed to disable touchpad")
+            return
+        }
+        if !c.isDisabled {
+            c.markDisabledLocked(events.ReasonTyping)
+            c.logger.Debug().Str("keyboard", keyboard).Msg("Touchpad disabled (typing detected)")
+        }
+    } else if !c.isDisabled {
+        // Disable touchpad if not already disabled
+        if err := c.disableLocked(events.ReasonTyping); err != nil {
             c.logger.Error().Err(err).Msg("Failed to disable touchpad")
             return
         }
-        c.isDisabled = true
         c.logger.Debug().Msg("Touchpad disabled (typing detected)")
     }
 
@@ -114,26 +205,200 @@ func (c *TypingDetectionConsumer) onCooldownExpired() {
     c.mu.Lock()
     defer c.mu.Unlock()
 
+    // Keys still held keep the touchpad suppressed; check again later
+    if c.isDisabled && c.keysHeldLocked() {
+        c.timer = time.AfterFunc(c.cooldown, c.onCooldownExpired)
+        return
+    }
+
+    // The cooldown may have been lengthened since the timer started
+    if remaining := c.cooldown - time.Since(c.lastKeyPress); remaining > 0 && c.isDisabled {
+        c.timer = time.AfterFunc(remaining, c.onCooldownExpired)
+        return
+    }
+
     // Check if we should re-enable (no recent keypresses)
     if time.Since(c.lastKeyPress) >= c.cooldown && c.isDisabled {
-        if err := c.touchpadCtrl.Enable(); err != nil {
+        if err := c.enableLocked(events.ReasonTyping); err != nil {
             c.logger.Error().Err(err).Msg("Failed to enable touchpad after cooldown")
             return
         }
-        c.isDisabled = false
         c.logger.Debug().Msg("Touchpad enabled (cooldown expired)")
     }
 }
 
+// OnIntentionalTouch ends a typing suppression as soon as the user
+// deliberately uses a touchpad (see touchpad.IntentCriteria), instead of
+// letting the cooldown swallow the start of the gesture. Manual and other
+// suppressions are left alone.
+func (c *TypingDetectionConsumer) OnIntentionalTouch(device, reason string) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    if !c.isDisabled || c.disabledReason != events.ReasonTyping {
+        return
+    }
+    c.stopTimerLocked()
+    // Keys still down are not waited for; the next press suppresses again
+    clear(c.held)
+    if err := c.enableLocked(events.ReasonTouch); err != nil {
+        c.logger.Error().Err(err).Msg("Failed to enable touchpad")
+        return
+    }
+    c.logger.Debug().Str("touchpad", device).Str("intent", reason).Msg("Touchpad enabled (deliberate touch)")
+}
+
+// keysHeldLocked reports whether a typing key is held, forgetting keys held
+// for longer than maxKeyHold. Callers must hold c.mu.
+func (c *TypingDetectionConsumer) keysHeldLocked() bool {
+    for key, since := range c.held {
+        if time.Since(since) > maxKeyHold {
+            delete(c.held, key)
+            c.logger.Warn().Str("keyboard", key.keyboard).Msg("Key held too long; assuming its release was lost")
+        }
+    }
+    return len(c.held) > 0
+}
+
+// Pause releases the touchpad and ignores typing and suppression commands
+// until Resume, e.g. while the user's session is inactive and its devices
+// are revoked.
+func (c *TypingDetectionConsumer) Pause(reason events.SuppressionReason) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    c.stopTimerLocked()
+    clear(c.held)
+    if c.isDisabled {
+        if err := c.enableLocked(reason); err != nil {
+            c.logger.Warn().Err(err).Msg("Failed to enable touchpad for pause")
+            c.markEnabledLocked(reason)
+        }
+    } else if c.touchpadCtrl.IsDisabled() {
+        // Never leave a grab behind for the next user
+        if err := c.touchpadCtrl.Enable(); err != nil {
+            c.logger.Warn().Err(err).Msg("Failed to enable touchpad for pause")
+        }
+    }
+    if !c.paused {
+        c.paused = true
+        c.logger.Info().Str("reason", string(reason)).Msg("Palm rejection paused")
+    }
+}
+
+// Resume ends a Pause.
+func (c *TypingDetectionConsumer) Resume() {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if c.paused {
+        c.paused = false
+        c.logger.Info().Msg("Palm rejection resumed")
+    }
+}
+
+// ApplySettings changes the cooldown and whether palm rejection is on.
+// Turning palm rejection off releases a typing suppression right away.
+func (c *TypingDetectionConsumer) ApplySettings(s Settings) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    if s.Cooldown > 0 {
+        c.cooldown = s.Cooldown
+    }
+    c.off = s.Off
+    if c.off {
+        clear(c.held)
+    }
+    if c.off && c.isDisabled && c.disabledReason == events.ReasonTyping {
+        c.stopTimerLocked()
+        if err := c.enableLocked(events.ReasonTyping); err != nil {
+            c.logger.Warn().Err(err).Msg("Failed to enable touchpad")
+        }
+    }
+    c.logger.Info().Dur("cooldown", c.cooldown).Bool("off", c.off).Msg("Settings applied")
+}
+
+// SetMetrics enables keystroke and suppression instrumentation.
+// Call before Start.
+func (c *TypingDetectionConsumer) SetMetrics(m *metrics.Metrics) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.metrics = m
+}
+
+// disableLocked grabs the touchpad and publishes TouchpadSuppressed.
+// Callers must hold c.mu.
+func (c *TypingDetectionConsumer) disableLocked(reason events.SuppressionReason) error {
+    if err := c.touchpadCtrl.Disable(); err != nil {
+        return err
+    }
+    c.markDisabledLocked(reason)
+    return nil
+}
+
+// markDisabledLocked records that the touchpad was grabbed and publishes
+// TouchpadSuppressed. Callers must hold c.mu.
+func (c *TypingDetectionConsumer) markDisabledLocked(reason events.SuppressionReason) {
+    c.isDisabled = true
+    c.disabledAt = time.Now()
+    c.disabledReason = reason
+    c.metrics.ObserveSuppression(string(reason))
+    c.stateFeed.Publish(events.TouchpadStateEvent{
+        Event:  events.TouchpadSuppressed,
+        Reason: reason,
+        Time:   c.disabledAt,
+    })
+}
+
+// enableLocked releases the touchpad and publishes TouchpadRestored with
+// the time spent suppressed. Callers must hold c.mu.
+func (c *TypingDetectionConsumer) enableLocked(reason events.SuppressionReason) error {
+    if err := c.touchpadCtrl.Enable(); err != nil {
+        return err
+    }
+    c.markEnabledLocked(reason)
+    return nil
+}
+
+// markEnabledLocked records that the touchpad is no longer suppressed and
+// publishes TouchpadRestored. Stop, Pause and suspend call it even when
+// the release failed, since they stop tracking the suppression either way.
+// Callers must hold c.mu.
+func (c *TypingDetectionConsumer) markEnabledLocked(reason events.SuppressionReason) {
+    now := time.Now()
+    c.isDisabled = false
+    c.metrics.ObserveRestore(string(reason), now.Sub(c.disabledAt))
+    c.stateFeed.Publish(events.TouchpadStateEvent{
+        Event:    events.TouchpadRestored,
+        Reason:   reason,
+        Time:     now,
+        Duration: now.Sub(c.disabledAt),
+    })
+}
+
+func (c *TypingDetectionConsumer) stopTimerLocked() {
+    if c.timer != nil {
+        c.timer.Stop()
+        c.timer = nil
+    }
+}
+
 // systemEventLoop handles system events (suspend, resume).
 func (c *TypingDetectionConsumer) systemEventLoop() {
-    sub := c.systemEventBus.Subscribe()
+    // Coalesce so a burst of manual commands can never push a suspend out.
+    sub := c.systemEventBus.Subscribe(
+        events.WithName("typing_detection"),
+        events.WithPolicy(events.CoalesceLatest),
+    )
 
     for {
         select {
         case <-c.ctx.Done():
             return
-        case event := <-sub:
+        case event, ok := <-sub:
+            if !ok {
+                return
+            }
             c.handleSystemEvent(event)
         }
     }
@@ -146,16 +411,13 @@ func (c *TypingDetectionConsumer) handleSystemEvent(event events.SystemEvent) {
         c.mu.Lock()
         // Ensure touchpad is enabled before suspend
         if c.isDisabled {
-            if err := c.touchpadCtrl.Enable(); err != nil {
+            if err := c.enableLocked(events.ReasonSuspend); err != nil {
                 c.logger.Warn().Err(err).Msg("Failed to enable touchpad for suspend")
+                c.markEnabledLocked(events.ReasonSuspend)
             }
-            c.isDisabled = false
         }
         // Stop the timer
-        if c.timer != nil {
-            c.timer.Stop()
-            c.timer = nil
-        }
+        c.stopTimerLocked()
         c.mu.Unlock()
         c.logger.Debug().Msg("Touchpad enabled for suspend")
 
@@ -165,60 +427,61 @@ func (c *TypingDetectionConsumer) handleSystemEvent(event events.SystemEvent) {
 
     case events.TouchpadDisable:
         c.mu.Lock()
-        if !c.isDisabled {
+        if c.paused {
+            c.logger.Info().Msg("Ignoring touchpad disable while paused")
+        } else if !c.isDisabled {
+            if err := c.disableLocked(events.ReasonManual); err != nil {
+                c.logger.Error().Err(err).Msg("Failed to disable touchpad via pipe command")
+            } else {
+                c.logger.Info().Msg("Touchpad disabled via pipe command")
+            }
+        } else if c.disabledReason != events.ReasonManual {
+            // Typing may have suppressed only one keyboard's touchpads
             if err := c.touchpadCtrl.Disable(); err != nil {
                 c.logger.Error().Err(err).Msg("Failed to disable touchpad via pipe command")
             } else {
-                c.isDisabled = true
+                // The typing episode ends here and a manual one begins
+                c.metrics.ObserveRestore(string(c.disabledReason), time.Since(c.disabledAt))
+                c.markDisabledLocked(events.ReasonManual)
                 c.logger.Info().Msg("Touchpad disabled via pipe command")
             }
         }
         // Stop any cooldown timer since this is a manual action
-        if c.timer != nil {
-            c.timer.Stop()
-            c.timer = nil
-        }
+        c.stopTimerLocked()
         c.mu.Unlock()
 
     case events.TouchpadEnable:
         c.mu.Lock()
         if c.isDisabled {
-            if err := c.touchpadCtrl.Enable(); err != nil {
+            if err := c.enableLocked(events.ReasonManual); err != nil {
                 c.logger.Error().Err(err).Msg("Failed to enable touchpad via pipe command")
             } else {
-                c.isDisabled = false
                 c.logger.Info().Msg("Touchpad enabled via pipe command")
             }
         }
         // Stop any cooldown timer
-        if c.timer != nil {
-            c.timer.Stop()
-            c.timer = nil
-        }
+        c.stopTimerLocked()
         c.mu.Unlock()
 
     case events.TouchpadToggle:
         c.mu.Lock()
-        if c.isDisabled {
-            if err := c.touchpadCtrl.Enable(); err != nil {
+        if c.paused {
+            c.logger.Info().Msg("Ignoring touchpad toggle while paused")
+        } else if c.isDisabled {
+            if err := c.enableLocked(events.ReasonManual); err != nil {
                 c.logger.Error().Err(err).Msg("Failed to enable touchpad via pipe command")
             } else {
-                c.isDisabled = false
                 c.logger.Info().Msg("Touchpad enabled via pipe command (toggle)")
             }
         } else {
-            if err := c.touchpadCtrl.Disable(); err != nil {
+            if err := c.disableLocked(events.ReasonManual); err != nil {
                 c.logger.Error().Err(err).Msg("Failed to disable touchpad via pipe command")
             } else {
-                c.isDisabled = true
                 c.logger.Info().Msg("Touchpad disabled via pipe command (toggle)")
             }
         }
         // Stop any cooldown timer since this is a manual action
-        if c.timer != nil {
-            c.timer.Stop()
-            c.timer = nil
-        }
+        c.stopTimerLocked()
         c.mu.Unlock()
     }
 }
@@ -229,3 +492,69 @@ func (c *TypingDetectionConsumer) IsDisabled() bool {
     defer c.mu.Unlock()
     return c.isDisabled
 }
+
+// CooldownRemaining returns how long until a typing suppression ends, or
+// zero if the touchpad is enabled or was disabled manually.
+func (c *TypingDetectionConsumer) CooldownRemaining() time.Duration {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if !c.isDisabled || c.disabledReason != events.ReasonTyping {
+        return 0
+    }
+    if remaining := c.cooldown - time.Since(c.lastKeyPress); remaining > 0 {
+        return remaining
+    }
+    return 0
+}
+
+// CurrentState returns the current suppression state as a state event, so
+// observers can render the state before the first change arrives.
+func (c *TypingDetectionConsumer) CurrentState() events.TouchpadStateEvent {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if c.isDisabled {
+        return events.TouchpadStateEvent{
+            Event:  events.TouchpadSuppressed,
+            Reason: c.disabledReason,
+            Time:   c.disabledAt,
+        }
+    }
+    return events.TouchpadStateEvent{Event: events.TouchpadRestored, Time: time.Now()}
+}
+
+// SubscribeState returns a stream of TouchpadSuppressed/TouchpadRestored
+// events and a function to cancel the subscription.
+func (c *TypingDetectionConsumer) SubscribeState() (<-chan events.TouchpadStateEvent, func()) {
+    return c.stateFeed.Subscribe(0)
+}
+
+// TypingStatus is a snapshot of the consumer state reported by the control interface.
+type TypingStatus struct {
+    Disabled     bool                     `json:"disabled"`
+    Paused       bool                     `json:"paused,omitempty"`
+    Off          bool                     `json:"off,omitempty"`
+    Reason       events.SuppressionReason `json:"reason,omitempty"`
+    CooldownMs   int64                    `json:"cooldown_ms"`
+    LastKeyAgoMs int64                    `json:"last_key_ago_ms,omitempty"`
+    KeysHeld     int                      `json:"keys_held,omitempty"`
+}
+
+// Status returns a snapshot of the consumer state.
+func (c *TypingDetectionConsumer) Status() TypingStatus {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    status := TypingStatus{
+        Disabled:   c.isDisabled,
+        Paused:     c.paused,
+        Off:        c.off,
+        CooldownMs: c.cooldown.Milliseconds(),
+        KeysHeld:   len(c.held),
+    }
+    if c.isDisabled {
+        status.Reason = c.disabledReason
+    }
+    if !c.lastKeyPress.IsZero() {
+        status.LastKeyAgoMs = time.Since(c.lastKeyPress).Milliseconds()
+    }
+    return status
+}
@@ -5,11 +5,13 @@ import (
 	"testing"
 	"time"
 
+	evdev "github.com/holoplot/go-evdev"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
 )
 
 // MockTouchpadController is a mock implementation of touchpad.TouchpadController
@@ -113,4 +115,257 @@ func TestTypingDetectionConsumer_DisableError(t *testing.T) {
 
 	err = consumer.Stop()
 	assert.NoError(t, err)
-}
\ No newline at end of file
+}
+
+func TestTypingDetectionConsumer_StateEvents(t *testing.T) {
+	mockCtrl := new(MockTouchpadController)
+	eventBus := events.NewSystemEventBus(zerolog.Nop())
+
+	consumer := NewTypingDetectionConsumer(
+		nil,
+		mockCtrl,
+		eventBus,
+		20*time.Millisecond,
+		zerolog.Nop(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, consumer.Start(ctx))
+
+	states, unsubscribe := consumer.SubscribeState()
+	defer unsubscribe()
+
+	mockCtrl.On("Disable").Return(nil).Once()
+	mockCtrl.On("Enable").Return(nil).Once()
+	consumer.OnKeyPress()
+
+	suppressed := <-states
+	assert.Equal(t, events.TouchpadSuppressed, suppressed.Event)
+	assert.Equal(t, events.ReasonTyping, suppressed.Reason)
+	assert.Equal(t, events.TouchpadSuppressed, consumer.CurrentState().Event)
+
+	select {
+	case restored := <-states:
+		assert.Equal(t, events.TouchpadRestored, restored.Event)
+		assert.Equal(t, events.ReasonTyping, restored.Reason)
+		assert.GreaterOrEqual(t, restored.Duration, 20*time.Millisecond)
+	case <-time.After(time.Second):
+		t.Fatal("touchpad not restored after cooldown")
+	}
+	mockCtrl.AssertExpectations(t)
+
+	assert.NoError(t, consumer.Stop())
+}
+
+func TestTypingDetectionConsumer_StateOnFailedRelease(t *testing.T) {
+	mockCtrl := new(MockTouchpadController)
+	eventBus := events.NewSystemEventBus(zerolog.Nop())
+
+	consumer := NewTypingDetectionConsumer(nil, mockCtrl, eventBus, time.Minute, zerolog.Nop())
+
+	states, unsubscribe := consumer.SubscribeState()
+	defer unsubscribe()
+
+	mockCtrl.On("Disable").Return(nil).Once()
+	consumer.OnKeyPress()
+	assert.Equal(t, events.TouchpadSuppressed, (<-states).Event)
+
+	// A failed release on suspend still ends the suppression for observers
+	mockCtrl.On("Enable").Return(assert.AnError).Once()
+	consumer.handleSystemEvent(events.LaptopSuspend)
+	restored := <-states
+	assert.Equal(t, events.TouchpadRestored, restored.Event)
+	assert.Equal(t, events.ReasonSuspend, restored.Reason)
+	assert.False(t, consumer.IsDisabled())
+	mockCtrl.AssertExpectations(t)
+}
+
+// MockRoutingController also implements touchpad.KeyboardRouter.
+type MockRoutingController struct {
+	MockTouchpadController
+}
+
+func (m *MockRoutingController) DisableFor(keyboard string) error {
+	args := m.Called(keyboard)
+	if args.Error(0) == nil {
+		m.disabled = true
+	}
+	return args.Error(0)
+}
+
+func TestTypingDetectionConsumer_RoutesByKeyboard(t *testing.T) {
+	mockCtrl := new(MockRoutingController)
+	eventBus := events.NewSystemEventBus(zerolog.Nop())
+
+	consumer := NewTypingDetectionConsumer(nil, mockCtrl, eventBus, time.Hour, zerolog.Nop())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, consumer.Start(ctx))
+
+	// Without a keyboard path everything is suppressed as before
+	mockCtrl.On("Disable").Return(nil).Once()
+	consumer.OnKeyPress()
+	assert.True(t, consumer.IsDisabled())
+
+	// Every routed key press reaches the controller, even while suppressed,
+	// so typing on a second keyboard adds its touchpads
+	mockCtrl.On("DisableFor", "/dev/input/event12").Return(nil).Twice()
+	mockCtrl.On("DisableFor", "/dev/input/event2").Return(nil).Once()
+	consumer.OnKeyPressFrom("/dev/input/event12")
+	consumer.OnKeyPressFrom("/dev/input/event12")
+	consumer.OnKeyPressFrom("/dev/input/event2")
+
+	mockCtrl.On("Enable").Return(nil).Once()
+	assert.NoError(t, consumer.Stop())
+	mockCtrl.AssertExpectations(t)
+}
+
+func TestTypingDetectionConsumer_Pause(t *testing.T) {
+	mockCtrl := new(MockTouchpadController)
+	eventBus := events.NewSystemEventBus(zerolog.Nop())
+	consumer := NewTypingDetectionConsumer(nil, mockCtrl, eventBus, time.Minute, zerolog.Nop())
+	assert.NoError(t, consumer.Start(context.Background()))
+
+	mockCtrl.On("Disable").Return(nil).Once()
+	consumer.OnKeyPress()
+	assert.True(t, consumer.IsDisabled())
+
+	// Pausing releases the touchpad right away
+	mockCtrl.On("Enable").Return(nil).Once()
+	consumer.Pause(events.ReasonSession)
+	assert.False(t, consumer.IsDisabled())
+	assert.True(t, consumer.Status().Paused)
+
+	// Typing and manual suppression are ignored while paused
+	consumer.OnKeyPress()
+	consumer.handleSystemEvent(events.TouchpadToggle)
+	assert.False(t, consumer.IsDisabled())
+	mockCtrl.AssertExpectations(t)
+
+	consumer.Resume()
+	mockCtrl.On("Disable").Return(nil).Once()
+	consumer.OnKeyPress()
+	assert.True(t, consumer.IsDisabled())
+
+	mockCtrl.On("Enable").Return(nil).Once()
+	assert.NoError(t, consumer.Stop())
+	mockCtrl.AssertExpectations(t)
+}
+
+func TestTypingDetectionConsumer_ApplySettings(t *testing.T) {
+	mockCtrl := new(MockTouchpadController)
+	eventBus := events.NewSystemEventBus(zerolog.Nop())
+	consumer := NewTypingDetectionConsumer(nil, mockCtrl, eventBus, 50*time.Millisecond, zerolog.Nop())
+	assert.NoError(t, consumer.Start(context.Background()))
+
+	// Lengthening the cooldown while it runs keeps the touchpad suppressed
+	mockCtrl.On("Disable").Return(nil).Once()
+	consumer.OnKeyPress()
+	consumer.ApplySettings(Settings{Cooldown: 200 * time.Millisecond})
+	time.Sleep(100 * time.Millisecond)
+	assert.True(t, consumer.IsDisabled())
+
+	mockCtrl.On("Enable").Return(nil).Once()
+	assert.Eventually(t, func() bool { return !consumer.IsDisabled() }, time.Second, 10*time.Millisecond)
+
+	// Off ignores typing
+	consumer.ApplySettings(Settings{Off: true})
+	consumer.OnKeyPress()
+	assert.False(t, consumer.IsDisabled())
+	assert.True(t, consumer.Status().Off)
+	assert.Equal(t, int64(200), consumer.Status().CooldownMs, "a zero cooldown keeps the current one")
+	mockCtrl.AssertExpectations(t)
+
+	assert.NoError(t, consumer.Stop())
+}
+
+func TestTypingDetectionConsumer_HeldKeys(t *testing.T) {
+	mockCtrl := new(MockTouchpadController)
+	eventBus := events.NewSystemEventBus(zerolog.Nop())
+	consumer := NewTypingDetectionConsumer(nil, mockCtrl, eventBus, 50*time.Millisecond, zerolog.Nop())
+	assert.NoError(t, consumer.Start(context.Background()))
+	key := func(code evdev.EvCode, action touchpad.KeyAction) {
+		consumer.OnKeyEvent(touchpad.KeyEvent{Keyboard: "/dev/input/event3", Code: code, Action: action, Time: time.Now()})
+	}
+
+	// Holding Backspace keeps the touchpad suppressed past the cooldown
+	mockCtrl.On("Disable").Return(nil).Once()
+	key(evdev.KEY_BACKSPACE, touchpad.KeyPress)
+	for i := 0; i < 5; i++ {
+		time.Sleep(30 * time.Millisecond)
+		key(evdev.KEY_BACKSPACE, touchpad.KeyRepeat)
+	}
+	assert.True(t, consumer.IsDisabled())
+	assert.Equal(t, 1, consumer.Status().KeysHeld)
+
+	// The cooldown starts over on release
+	key(evdev.KEY_BACKSPACE, touchpad.KeyRelease)
+	time.Sleep(20 * time.Millisecond)
+	assert.True(t, consumer.IsDisabled())
+	mockCtrl.On("Enable").Return(nil).Once()
+	assert.Eventually(t, func() bool { return !consumer.IsDisabled() }, time.Second, 5*time.Millisecond)
+
+	// Modifiers held for a gesture do not keep it suppressed
+	mockCtrl.On("Disable").Return(nil).Once()
+	key(evdev.KEY_LEFTCTRL, touchpad.KeyPress)
+	mockCtrl.On("Enable").Return(nil).Once()
+	assert.Eventually(t, func() bool { return !consumer.IsDisabled() }, time.Second, 5*time.Millisecond)
+	key(evdev.KEY_LEFTCTRL, touchpad.KeyRelease)
+
+	// A key whose release was lost is given up on eventually
+	mockCtrl.On("Disable").Return(nil).Once()
+	key(evdev.KEY_A, touchpad.KeyPress)
+	consumer.mu.Lock()
+	for k := range consumer.held {
+		consumer.held[k] = time.Now().Add(-2 * maxKeyHold)
+	}
+	consumer.mu.Unlock()
+	mockCtrl.On("Enable").Return(nil).Once()
+	assert.Eventually(t, func() bool { return !consumer.IsDisabled() }, time.Second, 5*time.Millisecond)
+	assert.Zero(t, consumer.Status().KeysHeld)
+
+	mockCtrl.AssertExpectations(t)
+	assert.NoError(t, consumer.Stop())
+}
+
+func TestTypingDetectionConsumer_IntentionalTouch(t *testing.T) {
+	mockCtrl := new(MockTouchpadController)
+	eventBus := events.NewSystemEventBus(zerolog.Nop())
+	consumer := NewTypingDetectionConsumer(nil, mockCtrl, eventBus, time.Minute, zerolog.Nop())
+	assert.NoError(t, consumer.Start(context.Background()))
+	states, cancel := consumer.SubscribeState()
+	defer cancel()
+
+	mockCtrl.On("Disable").Return(nil).Once()
+	consumer.OnKeyEvent(touchpad.KeyEvent{Keyboard: "/dev/input/event3", Code: evdev.KEY_A, Action: touchpad.KeyPress})
+	assert.True(t, consumer.IsDisabled())
+	<-states
+
+	// A deliberate touch ends the suppression without waiting for the
+	// cooldown or the key release
+	mockCtrl.On("Enable").Return(nil).Once()
+	consumer.OnIntentionalTouch("/dev/input/event5", touchpad.IntentClick)
+	assert.False(t, consumer.IsDisabled())
+	assert.Equal(t, events.ReasonTouch, (<-states).Reason)
+	assert.Zero(t, consumer.Status().KeysHeld)
+
+	// A pipe disable turns a typing suppression into a manual one
+	mockCtrl.On("Disable").Return(nil).Twice()
+	consumer.OnKeyPress()
+	typing := <-states
+	consumer.handleSystemEvent(events.TouchpadDisable)
+	manual := <-states
+	assert.Equal(t, events.TouchpadSuppressed, manual.Event)
+	assert.Equal(t, events.ReasonManual, manual.Reason)
+	assert.False(t, manual.Time.Before(typing.Time))
+	assert.Equal(t, events.ReasonManual, consumer.CurrentState().Reason)
+
+	// A manual suppression stays
+	consumer.OnIntentionalTouch("/dev/input/event5", touchpad.IntentFingers)
+	assert.True(t, consumer.IsDisabled())
+
+	mockCtrl.On("Enable").Return(nil).Once()
+	assert.NoError(t, consumer.Stop())
+	mockCtrl.AssertExpectations(t)
+}
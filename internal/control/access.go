@@ -0,0 +1,103 @@
+package control
+
+import (
+	"errors"
+	"net"
+	"os"
+	"os/user"
+	"slices"
+	"strconv"
+	"syscall"
+)
+
+// AnyCommand is the Policy key whose rule applies to commands without a
+// rule of their own.
+const AnyCommand = "*"
+
+// Rule lists the users and groups allowed to run a command.
+type Rule struct {
+	UIDs []int
+	GIDs []int
+}
+
+// Policy maps command names to the rule for that command. A command
+// without a rule (and no AnyCommand rule) may be run by anyone who can
+// connect to the socket. Root and the daemon's own user are always allowed.
+type Policy map[string]Rule
+
+// Peer is the identity of a connected client.
+type Peer struct {
+	UID    int
+	GID    int
+	Groups []int
+}
+
+// Allowed reports whether peer may run the named command.
+func (p Policy) Allowed(name string, peer Peer) bool {
+	if peer.UID == 0 || peer.UID == os.Geteuid() {
+		return true
+	}
+	rule, ok := p[name]
+	if !ok {
+		if rule, ok = p[AnyCommand]; !ok {
+			return true
+		}
+	}
+	if slices.Contains(rule.UIDs, peer.UID) || slices.Contains(rule.GIDs, peer.GID) {
+		return true
+	}
+	for _, gid := range peer.Groups {
+		if slices.Contains(rule.GIDs, gid) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupGroups returns the supplementary groups of a user from the user
+// database. Replaced in tests.
+var lookupGroups = func(uid int) ([]int, error) {
+	u, err := user.LookupId(strconv.Itoa(uid))
+	if err != nil {
+		return nil, err
+	}
+	ids, err := u.GroupIds()
+	if err != nil {
+		return nil, err
+	}
+	var gids []int
+	for _, id := range ids {
+		if gid, err := strconv.Atoi(id); err == nil {
+			gids = append(gids, gid)
+		}
+	}
+	return gids, nil
+}
+
+// peerCredentials returns the identity of the process on the other end of
+// a Unix socket connection, as recorded by the kernel when it connected.
+func peerCredentials(conn net.Conn) (Peer, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return Peer{}, errors.New("not a unix socket connection")
+	}
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return Peer{}, err
+	}
+	var cred *syscall.Ucred
+	var credErr error
+	if err := raw.Control(func(fd uintptr) {
+		cred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil {
+		return Peer{}, err
+	}
+	if credErr != nil {
+		return Peer{}, credErr
+	}
+
+	peer := Peer{UID: int(cred.Uid), GID: int(cred.Gid)}
+	// A user missing from the database just has no supplementary groups
+	peer.Groups, _ = lookupGroups(peer.UID)
+	return peer, nil
+}
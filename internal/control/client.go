@@ -0,0 +1,50 @@
+package control
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// Send connects to the control socket at path, sends a command and copies
+// the response to w until the server closes the connection or ctx is done.
+// An "error: " response is returned as an error instead of being copied.
+func Send(ctx context.Context, path string, command string, w io.Writer) error {
+	if path == "" {
+		path = DefaultSocketPath
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", path)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", path, err)
+	}
+	defer conn.Close()
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	if _, err := fmt.Fprintln(conn, command); err != nil {
+		return fmt.Errorf("failed to send command: %w", err)
+	}
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if msg, ok := strings.CutPrefix(line, "error: "); ok {
+			return errors.New(msg)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
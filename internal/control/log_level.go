@@ -0,0 +1,30 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LevelSetter holds log levels that can change at runtime;
+// *logging.Levels implements it.
+type LevelSetter interface {
+	String() string
+	Update(spec string) error
+}
+
+// LogLevelHandler returns a handler that prints the current log levels, or
+// with arguments applies them first, e.g. "log_level kb_monitor=debug" or
+// "log_level kb_monitor=default" to drop an override again.
+func LogLevelHandler(levels LevelSetter) HandlerFunc {
+	return func(_ context.Context, args []string, w io.Writer) error {
+		if len(args) > 0 {
+			if err := levels.Update(strings.Join(args, ",")); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintln(w, levels.String())
+		return err
+	}
+}
@@ -0,0 +1,38 @@
+package control
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ProfileSelector selects the palm rejection profile in effect;
+// *profile.Selector implements it.
+type ProfileSelector interface {
+	String() string
+	Select(name string) error
+}
+
+// ProfileHandler returns a handler that prints the profile in effect, or
+// with an argument selects it first: "profile editor" forces the editor
+// profile, "profile auto" returns to choosing by application and user.
+func ProfileHandler(profiles ProfileSelector) HandlerFunc {
+	return func(_ context.Context, args []string, w io.Writer) error {
+		switch len(args) {
+		case 0:
+		case 1:
+			name := args[0]
+			if name == "auto" {
+				name = ""
+			}
+			if err := profiles.Select(name); err != nil {
+				return err
+			}
+		default:
+			return errors.New("usage: profile [name|auto]")
+		}
+		_, err := fmt.Fprintln(w, profiles.String())
+		return err
+	}
+}
@@ -0,0 +1,292 @@
+// Package control implements the daemon's Unix socket control interface.
+//
+// The protocol is line based: a client connects, writes one command line
+// (e.g. "status" or "touchpad_toggle") and reads the response until the
+// server closes the connection. Errors are reported as a single line
+// starting with "error: ".
+package control
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/endpoint"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/metrics"
+)
+
+// DefaultSocketPath is the Unix socket the control server listens on.
+const DefaultSocketPath = endpoint.DefaultDir + "/daemon.sock"
+
+// HandlerFunc handles one control command. args are the whitespace separated
+// words following the command name; everything written to w is sent to the
+// client. ctx is cancelled when the client disconnects or the server stops.
+type HandlerFunc func(ctx context.Context, args []string, w io.Writer) error
+
+// StatusFunc returns a JSON-serialisable snapshot for the "status" command.
+type StatusFunc func() any
+
+// Server accepts control connections on a Unix socket.
+type Server struct {
+	ctx            context.Context
+	cancel         context.CancelFunc
+	path           string
+	listener       net.Listener
+	ownsSocket     bool
+	perm           endpoint.Permissions
+	policy         Policy
+	systemEventBus *events.SystemEventBus
+	logger         zerolog.Logger
+	metrics        *metrics.Metrics
+	wg             sync.WaitGroup
+
+	mu       sync.RWMutex
+	handlers map[string]HandlerFunc
+	status   map[string]StatusFunc
+}
+
+// NewServer creates a control server. Commands known to events.CommandEvent
+// are published on the bus; others must be registered with Handle.
+func NewServer(path string, bus *events.SystemEventBus, logger zerolog.Logger) *Server {
+	if path == "" {
+		path = DefaultSocketPath
+	}
+	s := &Server{
+		path:           path,
+		perm:           endpoint.Private,
+		systemEventBus: bus,
+		logger:         logger.With().Str("component", "control_server").Logger(),
+		handlers:       make(map[string]HandlerFunc),
+		status:         make(map[string]StatusFunc),
+	}
+	s.Handle("status", s.handleStatus)
+	s.Handle("help", s.handleHelp)
+	return s
+}
+
+// Handle registers a handler for a command, replacing any existing one.
+func (s *Server) Handle(name string, handler HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[strings.ToLower(name)] = handler
+}
+
+// AddStatus registers a section of the "status" command output.
+func (s *Server) AddStatus(name string, fn StatusFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status[name] = fn
+}
+
+// SetMetrics enables counting of received commands. Call before Start.
+func (s *Server) SetMetrics(m *metrics.Metrics) {
+	s.metrics = m
+}
+
+// SetPermissions sets the owner, group and mode of the socket the server
+// creates; the default is endpoint.Private. Call before Start.
+func (s *Server) SetPermissions(perm endpoint.Permissions) {
+	s.perm = perm
+}
+
+// SetPolicy restricts commands to the users and groups in p. Call before
+// Start.
+func (s *Server) SetPolicy(p Policy) {
+	s.policy = p
+}
+
+// Path returns the socket path.
+func (s *Server) Path() string {
+	return s.path
+}
+
+// Start creates the socket and begins accepting connections.
+func (s *Server) Start(ctx context.Context) error {
+	if err := s.perm.Prepare(s.path, os.ModeSocket); err != nil {
+		return err
+	}
+	listener, err := net.Listen("unix", s.path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.path, err)
+	}
+	// Removed in Stop, once it has been checked to still be ours
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := s.perm.Apply(s.path); err != nil {
+		listener.Close()
+		os.Remove(s.path)
+		return err
+	}
+	s.ownsSocket = true
+	s.startAccepting(ctx, listener)
+	return nil
+}
+
+// StartListener begins accepting connections on a listener created by
+// someone else, e.g. passed in by systemd socket activation. The socket
+// file, its permissions and its removal are left to the creator.
+func (s *Server) StartListener(ctx context.Context, listener net.Listener) error {
+	if addr := listener.Addr(); addr != nil && addr.String() != "" {
+		s.path = addr.String()
+	}
+	s.startAccepting(ctx, listener)
+	return nil
+}
+
+func (s *Server) startAccepting(ctx context.Context, listener net.Listener) {
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	s.listener = listener
+	s.wg.Add(1)
+	go s.acceptLoop()
+
+	s.logger.Info().Str("path", s.path).Bool("socket_activated", !s.ownsSocket).Msg("Control server started")
+}
+
+// Stop closes the socket and waits for open connections to finish.
+func (s *Server) Stop() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+	}
+	s.wg.Wait()
+	if s.ownsSocket {
+		if rmErr := s.perm.Remove(s.path, os.ModeSocket); rmErr != nil {
+			s.logger.Warn().Err(rmErr).Msg("failed to remove control socket")
+		}
+	}
+	s.logger.Info().Msg("Control server stopped")
+	return err
+}
+
+func (s *Server) acceptLoop() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if s.ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				s.logger.Error().Err(err).Msg("failed to accept control connection")
+				continue
+			}
+			return
+		}
+		s.wg.Add(1)
+		go s.serve(conn)
+	}
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer s.wg.Done()
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return
+	}
+
+	// Close the connection as soon as the client hangs up or the server
+	// stops, so streaming handlers notice through ctx or a failed write.
+	go func() {
+		io.Copy(io.Discard, reader)
+		cancel()
+	}()
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return
+	}
+	name := strings.ToLower(fields[0])
+	s.logger.Info().Str("command", name).Msg("control command received")
+
+	if err := s.authorize(conn, name); err != nil {
+		s.logger.Warn().Err(err).Str("command", name).Msg("control command denied")
+		s.metrics.ObserveCommand("control", "denied")
+		fmt.Fprintln(conn, "error: permission denied")
+		return
+	}
+
+	if err := s.dispatch(ctx, name, fields[1:], conn); err != nil {
+		s.logger.Warn().Err(err).Str("command", name).Msg("control command failed")
+		fmt.Fprintf(conn, "error: %v\n", err)
+	}
+}
+
+// authorize checks the connected client against the policy, if any.
+func (s *Server) authorize(conn net.Conn, name string) error {
+	if s.policy == nil {
+		return nil
+	}
+	peer, err := peerCredentials(conn)
+	if err != nil {
+		return fmt.Errorf("failed to identify client: %w", err)
+	}
+	if !s.policy.Allowed(name, peer) {
+		return fmt.Errorf("uid %d is not allowed to run %q", peer.UID, name)
+	}
+	return nil
+}
+
+func (s *Server) dispatch(ctx context.Context, name string, args []string, w io.Writer) error {
+	s.mu.RLock()
+	handler, ok := s.handlers[name]
+	s.mu.RUnlock()
+	if ok {
+		s.metrics.ObserveCommand("control", name)
+		return handler(ctx, args, w)
+	}
+
+	if event, ok := events.CommandEvent(name); ok {
+		s.metrics.ObserveCommand("control", name)
+		s.systemEventBus.Publish(event)
+		_, err := fmt.Fprintln(w, "ok")
+		return err
+	}
+	s.metrics.ObserveCommand("control", "unknown")
+	return fmt.Errorf("unknown command %q", name)
+}
+
+func (s *Server) handleStatus(_ context.Context, _ []string, w io.Writer) error {
+	s.mu.RLock()
+	snapshot := make(map[string]any, len(s.status))
+	for name, fn := range s.status {
+		snapshot[name] = fn()
+	}
+	s.mu.RUnlock()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snapshot)
+}
+
+func (s *Server) handleHelp(_ context.Context, _ []string, w io.Writer) error {
+	s.mu.RLock()
+	names := events.Commands()
+	for name := range s.handlers {
+		names = append(names, name)
+	}
+	s.mu.RUnlock()
+
+	sort.Strings(names)
+	_, err := fmt.Fprintln(w, strings.Join(names, "\n"))
+	return err
+}
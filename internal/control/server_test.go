@@ -0,0 +1,251 @@
+package control
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+)
+
+func startServer(t *testing.T) (*Server, *events.SystemEventBus) {
+	t.Helper()
+	bus := events.NewSystemEventBus(zerolog.Nop())
+	server := NewServer(filepath.Join(t.TempDir(), "ctl.sock"), bus, zerolog.Nop())
+	require.NoError(t, server.Start(context.Background()))
+	t.Cleanup(func() {
+		server.Stop()
+		bus.Close()
+	})
+	return server, bus
+}
+
+func TestServer_Status(t *testing.T) {
+	server, _ := startServer(t)
+	server.AddStatus("touchpad", func() any {
+		return map[string]bool{"disabled": true}
+	})
+
+	var out bytes.Buffer
+	require.NoError(t, Send(context.Background(), server.Path(), "status", &out))
+
+	var status map[string]map[string]bool
+	require.NoError(t, json.Unmarshal(out.Bytes(), &status))
+	assert.True(t, status["touchpad"]["disabled"])
+}
+
+func TestServer_StartListener(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "activated.sock")
+	ln, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	// Like a listener from net.FileListener
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+
+	bus := events.NewSystemEventBus(zerolog.Nop())
+	defer bus.Close()
+	server := NewServer("/nonexistent/ctl.sock", bus, zerolog.Nop())
+	require.NoError(t, server.StartListener(context.Background(), ln))
+	assert.Equal(t, path, server.Path())
+
+	var out bytes.Buffer
+	require.NoError(t, Send(context.Background(), server.Path(), "help", &out))
+	assert.Contains(t, out.String(), "status")
+
+	server.Stop()
+	_, err = os.Stat(path)
+	assert.NoError(t, err, "an inherited socket is left to its creator")
+}
+
+func TestServer_EventCommand(t *testing.T) {
+	server, bus := startServer(t)
+	sub := bus.Subscribe()
+
+	var out bytes.Buffer
+	require.NoError(t, Send(context.Background(), server.Path(), "TOUCHPAD_TOGGLE", &out))
+	assert.Equal(t, "ok\n", out.String())
+
+	select {
+	case ev := <-sub:
+		assert.Equal(t, events.TouchpadToggle, ev)
+	case <-time.After(time.Second):
+		t.Fatal("event not published")
+	}
+}
+
+func TestServer_Handlers(t *testing.T) {
+	server, _ := startServer(t)
+	server.Handle("echo", func(_ context.Context, args []string, w io.Writer) error {
+		_, err := io.WriteString(w, args[0]+"\n")
+		return err
+	})
+
+	var out bytes.Buffer
+	require.NoError(t, Send(context.Background(), server.Path(), "echo hello", &out))
+	assert.Equal(t, "hello\n", out.String())
+
+	err := Send(context.Background(), server.Path(), "bogus", &out)
+	assert.EqualError(t, err, `unknown command "bogus"`)
+}
+
+type fakeStateSource struct {
+	feed *events.StateFeed
+}
+
+func (f *fakeStateSource) CurrentState() events.TouchpadStateEvent {
+	return events.TouchpadStateEvent{Event: events.TouchpadRestored}
+}
+
+func (f *fakeStateSource) SubscribeState() (<-chan events.TouchpadStateEvent, func()) {
+	return f.feed.Subscribe(0)
+}
+
+func TestServer_Watch(t *testing.T) {
+	server, _ := startServer(t)
+	source := &fakeStateSource{feed: events.NewStateFeed(zerolog.Nop())}
+	server.Handle("watch", WatchHandler(source))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reader, writer := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- Send(ctx, server.Path(), "watch", writer)
+		writer.Close()
+	}()
+
+	dec := json.NewDecoder(reader)
+	var first map[string]any
+	require.NoError(t, dec.Decode(&first))
+	assert.Equal(t, "TouchpadRestored", first["event"])
+
+	source.feed.Publish(events.TouchpadStateEvent{
+		Event:  events.TouchpadSuppressed,
+		Reason: events.ReasonTyping,
+	})
+	var second map[string]any
+	require.NoError(t, dec.Decode(&second))
+	assert.Equal(t, "TouchpadSuppressed", second["event"])
+	assert.Equal(t, "typing", second["reason"])
+	assert.Equal(t, true, second["suppressed"])
+
+	cancel()
+	go io.Copy(io.Discard, reader)
+	assert.NoError(t, <-done)
+}
+
+type fakeLevels struct {
+	spec string
+}
+
+func (f *fakeLevels) String() string { return f.spec }
+
+func (f *fakeLevels) Update(spec string) error {
+	if strings.Contains(spec, "loud") {
+		return errors.New(`unknown log level "loud"`)
+	}
+	f.spec += "," + spec
+	return nil
+}
+
+func TestServer_LogLevel(t *testing.T) {
+	server, _ := startServer(t)
+	levels := &fakeLevels{spec: "info"}
+	server.Handle("log_level", LogLevelHandler(levels))
+
+	var out bytes.Buffer
+	require.NoError(t, Send(context.Background(), server.Path(), "log_level", &out))
+	assert.Equal(t, "info\n", out.String())
+
+	out.Reset()
+	require.NoError(t, Send(context.Background(), server.Path(), "log_level kb_monitor=debug dock=warn", &out))
+	assert.Equal(t, "info,kb_monitor=debug,dock=warn\n", out.String())
+
+	err := Send(context.Background(), server.Path(), "log_level dock=loud", &out)
+	assert.EqualError(t, err, `unknown log level "loud"`)
+}
+
+type fakeProfiles struct {
+	selected string
+}
+
+func (f *fakeProfiles) String() string { return "selected=" + f.selected }
+
+func (f *fakeProfiles) Select(name string) error {
+	if name == "drawing" {
+		return errors.New(`unknown profile "drawing"`)
+	}
+	f.selected = name
+	return nil
+}
+
+func TestServer_Profile(t *testing.T) {
+	server, _ := startServer(t)
+	profiles := &fakeProfiles{selected: "editor"}
+	server.Handle("profile", ProfileHandler(profiles))
+
+	var out bytes.Buffer
+	require.NoError(t, Send(context.Background(), server.Path(), "profile", &out))
+	assert.Equal(t, "selected=editor\n", out.String())
+
+	out.Reset()
+	require.NoError(t, Send(context.Background(), server.Path(), "profile auto", &out))
+	assert.Equal(t, "selected=\n", out.String())
+
+	err := Send(context.Background(), server.Path(), "profile drawing", &out)
+	assert.EqualError(t, err, `unknown profile "drawing"`)
+	err = Send(context.Background(), server.Path(), "profile a b", &out)
+	assert.EqualError(t, err, "usage: profile [name|auto]")
+}
+
+func TestPolicy_Allowed(t *testing.T) {
+	policy := Policy{
+		"status":   {UIDs: []int{1000}},
+		AnyCommand: {GIDs: []int{2000}},
+	}
+	alice := Peer{UID: 1000, GID: 1000}
+	bob := Peer{UID: 1001, GID: 1001, Groups: []int{2000}}
+
+	assert.True(t, policy.Allowed("status", alice))
+	assert.False(t, policy.Allowed("status", bob), "a command's own rule replaces the wildcard")
+	assert.False(t, policy.Allowed("touchpad_toggle", alice))
+	assert.True(t, policy.Allowed("touchpad_toggle", bob), "supplementary groups count")
+	assert.True(t, policy.Allowed("status", Peer{UID: os.Geteuid()}), "the daemon's own user is always allowed")
+
+	assert.True(t, Policy{"status": {}}.Allowed("touchpad_toggle", alice), "no matching rule allows")
+}
+
+func TestServer_Policy(t *testing.T) {
+	server, _ := startServer(t)
+	server.SetPolicy(Policy{AnyCommand: {}})
+
+	var out bytes.Buffer
+	require.NoError(t, Send(context.Background(), server.Path(), "touchpad_toggle", &out))
+	assert.Equal(t, "ok\n", out.String(), "the client runs as the daemon's user")
+
+	conn, err := net.Dial("unix", server.Path())
+	require.NoError(t, err)
+	defer conn.Close()
+	peer, err := peerCredentials(conn)
+	require.NoError(t, err)
+	assert.Equal(t, os.Getuid(), peer.UID)
+}
+
+func TestServer_RefusesSymlink(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ctl.sock")
+	require.NoError(t, os.Symlink(filepath.Join(dir, "elsewhere"), path))
+
+	server := NewServer(path, nil, zerolog.Nop())
+	assert.ErrorContains(t, server.Start(context.Background()), "is a symlink")
+}
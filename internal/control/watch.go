@@ -0,0 +1,43 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+)
+
+// StateSource provides the touchpad suppression state and its changes.
+type StateSource interface {
+	CurrentState() events.TouchpadStateEvent
+	SubscribeState() (<-chan events.TouchpadStateEvent, func())
+}
+
+// WatchHandler returns a handler that streams state changes as JSON lines,
+// starting with the current state, until the client disconnects. It is
+// meant for status bars such as waybar and i3blocks.
+func WatchHandler(source StateSource) HandlerFunc {
+	return func(ctx context.Context, _ []string, w io.Writer) error {
+		states, cancel := source.SubscribeState()
+		defer cancel()
+
+		enc := json.NewEncoder(w)
+		if err := enc.Encode(source.CurrentState()); err != nil {
+			return nil // client went away
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case state, ok := <-states:
+				if !ok {
+					return nil
+				}
+				if err := enc.Encode(state); err != nil {
+					return nil
+				}
+			}
+		}
+	}
+}
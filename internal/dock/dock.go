@@ -0,0 +1,179 @@
+// Package dock detects whether the Zenbook Duo's detachable keyboard is
+// docked. Docked (on the lower screen, connected by pogo pins) the keyboard
+// is a USB device; detached it talks Bluetooth and its USB device disappears.
+package dock
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+)
+
+// sysBusUSB lists USB devices; tests point it at a fake tree.
+var sysBusUSB = "/sys/bus/usb/devices"
+
+// DefaultKeyboard is the USB ID of the Zenbook Duo 2024 (UX8406) keyboard.
+const DefaultKeyboard = "0b05:1b2c"
+
+// DefaultPollInterval is how often the USB bus is checked.
+const DefaultPollInterval = time.Second
+
+// USBID is a USB vendor:product pair.
+type USBID struct {
+	Vendor  uint16
+	Product uint16
+}
+
+// ParseUSBID parses "vvvv:pppp" in hex.
+func ParseUSBID(s string) (USBID, error) {
+	vendor, product, ok := strings.Cut(s, ":")
+	if !ok {
+		return USBID{}, fmt.Errorf("invalid USB ID %q: want vendor:product", s)
+	}
+	v, err := strconv.ParseUint(vendor, 16, 16)
+	if err != nil {
+		return USBID{}, fmt.Errorf("invalid USB vendor in %q: %w", s, err)
+	}
+	p, err := strconv.ParseUint(product, 16, 16)
+	if err != nil {
+		return USBID{}, fmt.Errorf("invalid USB product in %q: %w", s, err)
+	}
+	return USBID{Vendor: uint16(v), Product: uint16(p)}, nil
+}
+
+// String formats the ID as "vvvv:pppp".
+func (id USBID) String() string {
+	return fmt.Sprintf("%04x:%04x", id.Vendor, id.Product)
+}
+
+// USBPresent reports whether a USB device with the given ID is connected.
+func USBPresent(id USBID) bool {
+	entries, err := os.ReadDir(sysBusUSB)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		dir := filepath.Join(sysBusUSB, e.Name())
+		if readHex(filepath.Join(dir, "idVendor")) == int(id.Vendor) &&
+			readHex(filepath.Join(dir, "idProduct")) == int(id.Product) {
+			return true
+		}
+	}
+	return false
+}
+
+func readHex(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return -1
+	}
+	v, err := strconv.ParseUint(strings.TrimSpace(string(data)), 16, 16)
+	if err != nil {
+		return -1
+	}
+	return int(v)
+}
+
+// Detector polls the USB bus for the keyboard and publishes
+// USBKeyboardAttached/USBKeyboardDetached when the dock state changes. The
+// initial state is published on Start.
+type Detector struct {
+	ctx            context.Context
+	cancel         context.CancelFunc
+	keyboard       USBID
+	interval       time.Duration
+	systemEventBus *events.SystemEventBus
+	logger         zerolog.Logger
+	done           chan struct{}
+
+	mu     sync.Mutex
+	docked bool
+}
+
+// NewDetector creates a dock detector for the keyboard's USB ID.
+func NewDetector(keyboard USBID, interval time.Duration, systemEventBus *events.SystemEventBus, logger zerolog.Logger) *Detector {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	return &Detector{
+		keyboard:       keyboard,
+		interval:       interval,
+		systemEventBus: systemEventBus,
+		logger:         logger.With().Str("component", "dock").Str("keyboard", keyboard.String()).Logger(),
+	}
+}
+
+// Start publishes the current state and starts polling.
+func (d *Detector) Start(ctx context.Context) error {
+	d.ctx, d.cancel = context.WithCancel(ctx)
+	d.done = make(chan struct{})
+
+	d.mu.Lock()
+	d.docked = USBPresent(d.keyboard)
+	d.mu.Unlock()
+	d.publish(d.docked)
+	d.logger.Info().Bool("docked", d.docked).Msg("Dock detector started")
+
+	go d.pollLoop()
+	return nil
+}
+
+// Stop stops polling.
+func (d *Detector) Stop() error {
+	if d.cancel != nil {
+		d.cancel()
+		<-d.done
+	}
+	d.logger.Info().Msg("Dock detector stopped")
+	return nil
+}
+
+// Docked reports whether the keyboard was on the USB bus at the last poll.
+func (d *Detector) Docked() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.docked
+}
+
+func (d *Detector) pollLoop() {
+	defer close(d.done)
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			d.poll()
+		}
+	}
+}
+
+func (d *Detector) poll() {
+	docked := USBPresent(d.keyboard)
+	d.mu.Lock()
+	changed := docked != d.docked
+	d.docked = docked
+	d.mu.Unlock()
+	if changed {
+		d.logger.Info().Bool("docked", docked).Msg("Keyboard dock state changed")
+		d.publish(docked)
+	}
+}
+
+func (d *Detector) publish(docked bool) {
+	if docked {
+		d.systemEventBus.Publish(events.USBKeyboardAttached)
+	} else {
+		d.systemEventBus.Publish(events.USBKeyboardDetached)
+	}
+}
@@ -0,0 +1,129 @@
+package dock
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
+)
+
+var duoKeyboard = USBID{Vendor: 0x0b05, Product: 0x1b2c}
+
+// fakeUSBTree points the package at an empty /sys/bus/usb/devices and
+// returns a function that plugs (or unplugs) a device.
+func fakeUSBTree(t *testing.T) func(name, vendor, product string, present bool) {
+	t.Helper()
+	dir := t.TempDir()
+	old := sysBusUSB
+	sysBusUSB = dir
+	t.Cleanup(func() { sysBusUSB = old })
+
+	return func(name, vendor, product string, present bool) {
+		dev := filepath.Join(dir, name)
+		if !present {
+			require.NoError(t, os.RemoveAll(dev))
+			return
+		}
+		require.NoError(t, os.MkdirAll(dev, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dev, "idVendor"), []byte(vendor+"\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dev, "idProduct"), []byte(product+"\n"), 0644))
+	}
+}
+
+func TestParseUSBID(t *testing.T) {
+	id, err := ParseUSBID("0B05:1b2c")
+	require.NoError(t, err)
+	assert.Equal(t, duoKeyboard, id)
+	assert.Equal(t, "0b05:1b2c", id.String())
+
+	for _, bad := range []string{"", "0b05", "xyz:1b2c", "0b05:12345"} {
+		_, err := ParseUSBID(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestUSBPresent(t *testing.T) {
+	plug := fakeUSBTree(t)
+	plug("1-1", "046d", "c52b", true)
+	assert.False(t, USBPresent(duoKeyboard))
+
+	plug("3-5", "0b05", "1b2c", true)
+	assert.True(t, USBPresent(duoKeyboard))
+}
+
+func TestDetector(t *testing.T) {
+	plug := fakeUSBTree(t)
+	plug("3-5", "0b05", "1b2c", true)
+
+	bus := events.NewSystemEventBus(zerolog.Nop())
+	defer bus.Close()
+	sub := bus.Subscribe()
+
+	d := NewDetector(duoKeyboard, 5*time.Millisecond, bus, zerolog.Nop())
+	require.NoError(t, d.Start(context.Background()))
+	defer d.Stop()
+
+	next := func() events.SystemEvent {
+		select {
+		case e := <-sub:
+			return e
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for dock event")
+			return events.SystemEventNone
+		}
+	}
+
+	assert.Equal(t, events.USBKeyboardAttached, next(), "initial state is published")
+	assert.True(t, d.Docked())
+
+	plug("3-5", "", "", false)
+	assert.Equal(t, events.USBKeyboardDetached, next())
+	assert.False(t, d.Docked())
+
+	plug("3-5", "0b05", "1b2c", true)
+	assert.Equal(t, events.USBKeyboardAttached, next())
+}
+
+func TestPairedTouchpads(t *testing.T) {
+	pad := func(path string, bus evdev.EvCode, vendor, product uint16) *touchpad.InputDevice {
+		return &touchpad.InputDevice{
+			Path:           path,
+			ID:             touchpad.InputID{BusType: uint16(bus), Vendor: vendor, Product: product},
+			Classification: touchpad.Classification{Class: touchpad.ClassTouchpad},
+		}
+	}
+	usbPad := pad("/dev/input/event7", evdev.BUS_USB, 0x0b05, 0x1b2c)
+	btPad := pad("/dev/input/event12", evdev.BUS_BLUETOOTH, 0x0b05, 0x1b2d)
+	i2cPad := pad("/dev/input/event5", evdev.BUS_I2C, 0x04f3, 0x3195)
+	usbKeyboard := &touchpad.InputDevice{
+		Path:           "/dev/input/event6",
+		ID:             touchpad.InputID{BusType: uint16(evdev.BUS_USB), Vendor: 0x0b05, Product: 0x1b2c},
+		Classification: touchpad.Classification{Class: touchpad.ClassKeyboard},
+	}
+
+	tests := []struct {
+		name    string
+		docked  bool
+		devices []*touchpad.InputDevice
+		want    []string
+	}{
+		{"docked uses the USB touchpad", true, []*touchpad.InputDevice{i2cPad, usbKeyboard, usbPad, btPad}, []string{usbPad.Path}},
+		{"detached uses the Bluetooth touchpad", false, []*touchpad.InputDevice{usbPad, btPad}, []string{btPad.Path}},
+		{"detached without Bluetooth touchpad suppresses nothing", false, []*touchpad.InputDevice{usbPad}, []string{}},
+		{"no keyboard touchpad keeps all", true, []*touchpad.InputDevice{i2cPad, usbKeyboard}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PairedTouchpads(tt.docked, duoKeyboard, tt.devices))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package dock
+
+import (
+	evdev "github.com/holoplot/go-evdev"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
+)
+
+// PairedTouchpads returns the touchpads that are physically together with
+// the keyboard: its USB touchpad while docked, or a Bluetooth touchpad from
+// the same vendor while detached. It returns nil if no touchpad belongs to
+// the keyboard (e.g. on a laptop without a detachable keyboard), meaning
+// palm rejection should apply to every touchpad as before.
+func PairedTouchpads(docked bool, keyboard USBID, devices []*touchpad.InputDevice) []string {
+	var paired []string
+	var fromKeyboard bool
+	for _, d := range devices {
+		if d.Class != touchpad.ClassTouchpad || d.ID.Vendor != keyboard.Vendor {
+			continue
+		}
+		usb := d.ID.BusType == uint16(evdev.BUS_USB) && d.ID.Product == keyboard.Product
+		bluetooth := d.ID.BusType == uint16(evdev.BUS_BLUETOOTH)
+		if !usb && !bluetooth {
+			continue
+		}
+		fromKeyboard = true
+		if usb == docked {
+			paired = append(paired, d.Path)
+		}
+	}
+	if !fromKeyboard {
+		return nil
+	}
+	if paired == nil {
+		// The keyboard's touchpad for this state is not present (yet):
+		// suppress nothing rather than a touchpad on the other side.
+		paired = []string{}
+	}
+	return paired
+}
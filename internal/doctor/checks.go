@@ -0,0 +1,376 @@
+package doctor
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/config"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/privacy"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
+)
+
+// procDir is the procfs mount point; tests point it at a fake tree.
+var procDir = "/proc"
+
+// uinputPath is where virtual input devices are created; tests point it
+// elsewhere.
+var uinputPath = "/dev/uinput"
+
+const (
+	capDACOverride = 1
+	accessRW       = 0x2 | 0x4 // W_OK | R_OK
+)
+
+func checkConfig(r *Report, path string) *config.Config {
+	if path == "" {
+		path = config.DefaultPath
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		r.add("config", Fail, err.Error())
+		return nil
+	}
+	if err := cfg.Validate(); err != nil {
+		r.add("config", Fail, "invalid configuration", strings.Split(err.Error(), "\n")...)
+		return cfg
+	}
+	if _, statErr := os.Stat(path); statErr != nil {
+		r.add("config", Pass, fmt.Sprintf("%s not found, using defaults", path))
+	} else {
+		r.add("config", Pass, fmt.Sprintf("%s is valid", path))
+	}
+	return cfg
+}
+
+func checkPrivileges(r *Report) {
+	if os.Geteuid() == 0 {
+		r.add("privileges", Pass, "running as root")
+		return
+	}
+	if hasCapability(capDACOverride) {
+		r.add("privileges", Pass, "running with CAP_DAC_OVERRIDE")
+		return
+	}
+	if inGroup("input") {
+		r.add("privileges", Warn, "not root; relying on membership of the 'input' group")
+		return
+	}
+	r.add("privileges", Fail, "not root, no CAP_DAC_OVERRIDE and not in the 'input' group",
+		"run with sudo or as the systemd service")
+}
+
+// hasCapability reports whether the effective capability set contains bit.
+func hasCapability(bit uint) bool {
+	f, err := os.Open(filepath.Join(procDir, "self", "status"))
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if hex, ok := strings.CutPrefix(scanner.Text(), "CapEff:"); ok {
+			caps, err := strconv.ParseUint(strings.TrimSpace(hex), 16, 64)
+			return err == nil && caps&(1<<bit) != 0
+		}
+	}
+	return false
+}
+
+func inGroup(name string) bool {
+	group, err := user.LookupGroup(name)
+	if err != nil {
+		return false
+	}
+	gid, err := strconv.Atoi(group.Gid)
+	if err != nil {
+		return false
+	}
+	gids, err := os.Getgroups()
+	if err != nil {
+		return false
+	}
+	for _, g := range gids {
+		if g == gid {
+			return true
+		}
+	}
+	return os.Getegid() == gid
+}
+
+func checkInputAccess(r *Report) []*touchpad.InputDevice {
+	devices, err := touchpad.ScanInputDevices()
+	if err != nil {
+		r.add("input access", Fail, err.Error())
+		return nil
+	}
+	var denied []string
+	for _, d := range devices {
+		if err := syscall.Access(d.Path, accessRW); err != nil {
+			denied = append(denied, fmt.Sprintf("%s: %v", d.Path, err))
+		}
+	}
+	switch {
+	case len(devices) == 0:
+		r.add("input access", Fail, "no /dev/input/event* nodes found")
+	case len(denied) == len(devices):
+		r.add("input access", Fail, "no input device is readable and writable", denied...)
+	case len(denied) > 0:
+		r.add("input access", Warn, fmt.Sprintf("%d of %d input devices are not accessible", len(denied), len(devices)), denied...)
+	default:
+		r.add("input access", Pass, fmt.Sprintf("all %d input devices are accessible", len(devices)))
+	}
+	return devices
+}
+
+func checkDiscovery(r *Report, devices []*touchpad.InputDevice) []*touchpad.InputDevice {
+	var touchpads []*touchpad.InputDevice
+	var details []string
+	for _, d := range devices {
+		details = append(details, fmt.Sprintf("%s %q -> %s (%s)", d.Path, d.Name, d.Class, d.Rule))
+		if d.Class == touchpad.ClassTouchpad {
+			touchpads = append(touchpads, d)
+		}
+	}
+	if len(touchpads) == 0 {
+		r.add("touchpad discovery", Fail, "no touchpad device found", details...)
+		return nil
+	}
+	r.add("touchpad discovery", Pass, fmt.Sprintf("found %d touchpad(s)", len(touchpads)), details...)
+	return touchpads
+}
+
+func checkKeyboard(r *Report, logger zerolog.Logger) {
+	kb, err := touchpad.FindKeyboardDevice(logger)
+	if err != nil {
+		r.add("keyboard discovery", Fail, err.Error(),
+			`expected "keyd virtual keyboard" or "AT Translated Set 2 keyboard"`)
+		return
+	}
+	r.add("keyboard discovery", Pass, fmt.Sprintf("monitoring %s (%s)", kb.Path, kb.Name))
+}
+
+func checkKeyd(r *Report, devices []*touchpad.InputDevice) {
+	virtual := false
+	for _, d := range devices {
+		if strings.Contains(d.Name, "keyd virtual keyboard") {
+			virtual = true
+		}
+	}
+	running := len(findProcesses("keyd")) > 0
+	switch {
+	case running && virtual:
+		r.add("keyd", Pass, "keyd is running; typing is read from its virtual keyboard")
+	case running:
+		r.add("keyd", Warn, "keyd is running but its virtual keyboard was not found",
+			"keyd grabs the physical keyboard, so typing may go undetected")
+	case virtual:
+		r.add("keyd", Warn, "keyd virtual keyboard exists but no keyd process was found")
+	default:
+		r.add("keyd", Pass, "keyd is not running; typing is read from the physical keyboard")
+	}
+}
+
+// findProcesses returns the PIDs whose comm equals name.
+func findProcesses(name string) []int {
+	var pids []int
+	for _, pid := range listPIDs() {
+		if processName(pid) == name {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
+
+func listPIDs() []int {
+	entries, err := os.ReadDir(procDir)
+	if err != nil {
+		return nil
+	}
+	var pids []int
+	for _, e := range entries {
+		if pid, err := strconv.Atoi(e.Name()); err == nil {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
+
+func processName(pid int) string {
+	data, err := os.ReadFile(filepath.Join(procDir, strconv.Itoa(pid), "comm"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// deviceHolders maps each device path to the other processes that have it open.
+func deviceHolders(paths []string) map[string][]string {
+	wanted := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		wanted[p] = true
+	}
+	holders := make(map[string][]string)
+	self := os.Getpid()
+	for _, pid := range listPIDs() {
+		if pid == self {
+			continue
+		}
+		fdDir := filepath.Join(procDir, strconv.Itoa(pid), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, fd := range fds {
+			target, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil || !wanted[target] || seen[target] {
+				continue
+			}
+			seen[target] = true
+			holders[target] = append(holders[target], fmt.Sprintf("pid %d (%s)", pid, processName(pid)))
+		}
+	}
+	return holders
+}
+
+func checkHolders(r *Report, touchpads []*touchpad.InputDevice) map[string][]string {
+	paths := make([]string, 0, len(touchpads))
+	for _, t := range touchpads {
+		paths = append(paths, t.Path)
+	}
+	holders := deviceHolders(paths)
+	var details []string
+	for _, p := range paths {
+		for _, h := range holders[p] {
+			details = append(details, fmt.Sprintf("%s is open by %s", p, h))
+		}
+	}
+	if len(details) == 0 {
+		r.add("device holders", Pass, "no other process has a touchpad open")
+		return holders
+	}
+	r.add("device holders", Warn, fmt.Sprintf("%d other process(es) have a touchpad open; one that grabs it conflicts with palm rejection", len(details)), details...)
+	return holders
+}
+
+func checkGrab(r *Report, touchpads []*touchpad.InputDevice, holders map[string][]string, logger zerolog.Logger) {
+	for _, t := range touchpads {
+		name := "grab " + t.Path
+		ctrl := touchpad.NewController(t.Path, logger)
+		if err := ctrl.Open(); err != nil {
+			r.add(name, Fail, err.Error())
+			continue
+		}
+		err := ctrl.Disable()
+		if err == nil {
+			err = ctrl.Enable()
+		}
+		ctrl.Close()
+
+		switch {
+		case errors.Is(err, syscall.EBUSY):
+			r.add(name, Fail, "touchpad is already grabbed by another process", holders[t.Path]...)
+		case err != nil:
+			r.add(name, Fail, err.Error())
+		default:
+			r.add(name, Pass, "grab and ungrab succeeded")
+		}
+	}
+}
+
+// checkPrivacy states what the daemon records about key presses.
+func checkPrivacy(r *Report, keys privacy.KeyPolicy) {
+	if keys.RawCodes {
+		r.add("privacy", Warn, "raw key codes are logged (privacy.log_key_codes)", keys.Guarantee()...)
+		return
+	}
+	r.add("privacy", Pass, "what you type is not recorded", keys.Guarantee()...)
+}
+
+// checkUinput tells whether touchpads that pass clicks on while suppressed
+// can create their virtual device. With runAs set the daemon's privilege
+// helper opens /dev/uinput as root, so the node only has to exist;
+// otherwise the daemon opens it as whoever starts it, as doctor does.
+func checkUinput(r *Report, modes map[string]touchpad.Suppression, runAs string) {
+	var passthrough []string
+	for name, mode := range modes {
+		if mode != touchpad.SuppressGrab {
+			passthrough = append(passthrough, fmt.Sprintf("%s: %s", name, mode))
+		}
+	}
+	if len(passthrough) == 0 {
+		return
+	}
+	sort.Strings(passthrough)
+	if runAs != "" {
+		info, err := os.Stat(uinputPath)
+		if err == nil && info.Mode()&os.ModeCharDevice == 0 {
+			err = errors.New("not a character device")
+		}
+		if err != nil {
+			r.add("uinput", Warn, fmt.Sprintf("%s is unusable (%v); these touchpads will be grabbed completely", uinputPath, err), passthrough...)
+			return
+		}
+		r.add("uinput", Pass, fmt.Sprintf("%s is opened by the privilege helper as root after dropping to %s", uinputPath, runAs), passthrough...)
+		return
+	}
+	if err := syscall.Access(uinputPath, 0x2); err != nil {
+		r.add("uinput", Warn, fmt.Sprintf("%s is not writable (%v); these touchpads will be grabbed completely", uinputPath, err), passthrough...)
+		return
+	}
+	r.add("uinput", Pass, fmt.Sprintf("%s is writable", uinputPath), passthrough...)
+}
+
+func checkControlPath(r *Report, kind, path string) {
+	name := kind + " path"
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		dir := filepath.Dir(path)
+		if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+			dir = filepath.Dir(dir) // the daemon creates its runtime directory
+		}
+		if err := syscall.Access(dir, 0x2|0x1); err != nil {
+			r.add(name, Fail, fmt.Sprintf("%s cannot be created: %s is not writable", path, dir))
+			return
+		}
+		r.add(name, Pass, fmt.Sprintf("%s will be created when the daemon starts", path))
+		return
+	}
+	if err != nil {
+		r.add(name, Fail, err.Error())
+		return
+	}
+
+	mode := info.Mode()
+	switch {
+	case mode&os.ModeSymlink != 0:
+		r.add(name, Fail, fmt.Sprintf("%s is a symlink; the daemon refuses to use it", path))
+	case kind == "pipe" && mode&os.ModeNamedPipe == 0:
+		r.add(name, Fail, fmt.Sprintf("%s exists but is not a named pipe (%s); the daemon refuses to replace it", path, mode.Type()))
+	case kind == "socket" && mode&os.ModeSocket == 0:
+		r.add(name, Fail, fmt.Sprintf("%s exists but is not a socket (%s); the daemon refuses to replace it", path, mode.Type()))
+	case mode&0002 != 0:
+		r.add(name, Warn, fmt.Sprintf("%s is writable by every user (mode %s)", path, mode.Perm()))
+	case kind == "socket":
+		conn, err := net.Dial("unix", path)
+		if err != nil {
+			r.add(name, Warn, fmt.Sprintf("%s exists but nothing is listening (stale socket?)", path))
+			return
+		}
+		conn.Close()
+		r.add(name, Pass, fmt.Sprintf("%s is accepting connections; the daemon is running", path))
+	default:
+		r.add(name, Pass, fmt.Sprintf("%s exists (mode %s)", path, mode.Perm()))
+	}
+}
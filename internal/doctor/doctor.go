@@ -0,0 +1,107 @@
+// Package doctor diagnoses the environment the daemon runs in: device
+// discovery, permissions, conflicting grabbers, configuration and the
+// control paths. Each check yields a pass/warn/fail result.
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// Status is the outcome of a single check.
+type Status string
+
+const (
+	Pass Status = "pass"
+	Warn Status = "warn"
+	Fail Status = "fail"
+)
+
+// Check is the result of one diagnostic.
+type Check struct {
+	Name    string   `json:"name"`
+	Status  Status   `json:"status"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
+
+// Report is the ordered list of check results.
+type Report struct {
+	Checks []Check `json:"checks"`
+}
+
+func (r *Report) add(name string, status Status, message string, details ...string) {
+	r.Checks = append(r.Checks, Check{Name: name, Status: status, Message: message, Details: details})
+}
+
+// Failed reports whether any check failed.
+func (r Report) Failed() bool {
+	for _, c := range r.Checks {
+		if c.Status == Fail {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteText writes a human readable report.
+func (r Report) WriteText(w io.Writer) error {
+	for _, c := range r.Checks {
+		if _, err := fmt.Fprintf(w, "[%s] %s: %s\n", strings.ToUpper(string(c.Status)), c.Name, c.Message); err != nil {
+			return err
+		}
+		for _, d := range c.Details {
+			if _, err := fmt.Fprintf(w, "       %s\n", d); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the report as a JSON document.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		OK     bool    `json:"ok"`
+		Checks []Check `json:"checks"`
+	}{OK: !r.Failed(), Checks: r.Checks})
+}
+
+// Options configures a doctor run.
+type Options struct {
+	// ConfigPath is the configuration file to validate.
+	ConfigPath string
+	// SkipGrab disables the grab/ungrab test on each touchpad.
+	SkipGrab bool
+	Logger   zerolog.Logger
+}
+
+// Run executes all checks in order.
+func Run(opts Options) Report {
+	r := &Report{}
+	cfg := checkConfig(r, opts.ConfigPath)
+	checkPrivileges(r)
+	devices := checkInputAccess(r)
+	touchpads := checkDiscovery(r, devices)
+	checkKeyboard(r, opts.Logger)
+	checkKeyd(r, devices)
+	holders := checkHolders(r, touchpads)
+	if opts.SkipGrab {
+		r.add("grab", Warn, "grab/ungrab test skipped")
+	} else {
+		checkGrab(r, touchpads, holders, opts.Logger)
+	}
+	if cfg != nil {
+		checkControlPath(r, "pipe", cfg.PipePath)
+		checkControlPath(r, "socket", cfg.SocketPath)
+		checkPrivacy(r, cfg.Privacy.KeyPolicy())
+		checkUinput(r, cfg.Suppressions(), cfg.Privileges.RunAs)
+	}
+	return *r
+}
@@ -0,0 +1,189 @@
+package doctor
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/privacy"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
+)
+
+// fakeProc builds a minimal procfs with one process per entry of comms,
+// each holding the given device open, and points procDir at it.
+func fakeProc(t *testing.T, comms map[int]string, device string) {
+	t.Helper()
+	root := t.TempDir()
+	for pid, comm := range comms {
+		dir := filepath.Join(root, strconv.Itoa(pid))
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "fd"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "comm"), []byte(comm+"\n"), 0644))
+		require.NoError(t, os.Symlink(device, filepath.Join(dir, "fd", "3")))
+	}
+	old := procDir
+	procDir = root
+	t.Cleanup(func() { procDir = old })
+}
+
+func lastCheck(r *Report) Check {
+	return r.Checks[len(r.Checks)-1]
+}
+
+func TestCheckKeyd(t *testing.T) {
+	virtual := []*touchpad.InputDevice{{Path: "/dev/input/event9", Name: "keyd virtual keyboard"}}
+
+	tests := []struct {
+		name    string
+		comms   map[int]string
+		devices []*touchpad.InputDevice
+		status  Status
+	}{
+		{"running with virtual keyboard", map[int]string{100: "keyd"}, virtual, Pass},
+		{"running without virtual keyboard", map[int]string{100: "keyd"}, nil, Warn},
+		{"not running", map[int]string{100: "bash"}, nil, Pass},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeProc(t, tt.comms, "/dev/null")
+			r := &Report{}
+			checkKeyd(r, tt.devices)
+			assert.Equal(t, tt.status, lastCheck(r).Status)
+		})
+	}
+}
+
+func TestDeviceHolders(t *testing.T) {
+	fakeProc(t, map[int]string{200: "gnome-shell", 201: "bash"}, "/dev/input/event5")
+
+	holders := deviceHolders([]string{"/dev/input/event5"})
+	assert.ElementsMatch(t, []string{"pid 200 (gnome-shell)", "pid 201 (bash)"}, holders["/dev/input/event5"])
+}
+
+func TestCheckHolders(t *testing.T) {
+	pads := []*touchpad.InputDevice{{Path: "/dev/input/event5"}}
+
+	fakeProc(t, map[int]string{200: "evtest"}, "/dev/input/event5")
+	r := &Report{}
+	holders := checkHolders(r, pads)
+	assert.Equal(t, Warn, lastCheck(r).Status)
+	assert.Equal(t, []string{"/dev/input/event5 is open by pid 200 (evtest)"}, lastCheck(r).Details)
+	assert.Len(t, holders["/dev/input/event5"], 1)
+
+	fakeProc(t, map[int]string{200: "bash"}, "/dev/null")
+	r = &Report{}
+	checkHolders(r, pads)
+	assert.Equal(t, Pass, lastCheck(r).Status)
+	assert.Empty(t, lastCheck(r).Details)
+}
+
+func TestCheckControlPath(t *testing.T) {
+	dir := t.TempDir()
+
+	r := &Report{}
+	checkControlPath(r, "pipe", filepath.Join(dir, "new.pipe"))
+	assert.Equal(t, Pass, lastCheck(r).Status)
+	assert.Contains(t, lastCheck(r).Message, "will be created")
+
+	fifo := filepath.Join(dir, "daemon.pipe")
+	require.NoError(t, syscall.Mkfifo(fifo, 0600))
+	checkControlPath(r, "pipe", fifo)
+	assert.Equal(t, Pass, lastCheck(r).Status)
+
+	regular := filepath.Join(dir, "regular")
+	require.NoError(t, os.WriteFile(regular, nil, 0600))
+	checkControlPath(r, "socket", regular)
+	assert.Equal(t, Fail, lastCheck(r).Status)
+	assert.Contains(t, lastCheck(r).Message, "refuses to replace it")
+
+	sock := filepath.Join(dir, "ctl.sock")
+	listener, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+	defer listener.Close()
+	checkControlPath(r, "socket", sock)
+	assert.Equal(t, Pass, lastCheck(r).Status)
+	assert.Contains(t, lastCheck(r).Message, "daemon is running")
+
+	checkControlPath(r, "socket", filepath.Join(dir, "run", "ctl.sock"))
+	assert.Equal(t, Pass, lastCheck(r).Status, "the runtime directory is created by the daemon")
+
+	checkControlPath(r, "socket", filepath.Join(dir, "missing", "run", "ctl.sock"))
+	assert.Equal(t, Fail, lastCheck(r).Status)
+}
+
+func TestCheckConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"cooldown": "1ms"}`), 0644))
+
+	r := &Report{}
+	cfg := checkConfig(r, path)
+	assert.NotNil(t, cfg)
+	assert.Equal(t, Fail, lastCheck(r).Status)
+	assert.Contains(t, lastCheck(r).Details[0], "cooldown 1ms out of range")
+}
+
+func TestCheckPrivacy(t *testing.T) {
+	r := &Report{}
+	checkPrivacy(r, privacy.KeyPolicy{})
+	assert.Equal(t, Pass, lastCheck(r).Status)
+	assert.Contains(t, lastCheck(r).Details[0], "only as a class")
+
+	checkPrivacy(r, privacy.KeyPolicy{RawCodes: true})
+	assert.Equal(t, Warn, lastCheck(r).Status)
+	assert.Contains(t, lastCheck(r).Details[0], "raw key codes")
+}
+
+func TestCheckUinput(t *testing.T) {
+	old := uinputPath
+	uinputPath = filepath.Join(t.TempDir(), "uinput")
+	defer func() { uinputPath = old }()
+
+	r := &Report{}
+	checkUinput(r, map[string]touchpad.Suppression{"/dev/input/event5": touchpad.SuppressGrab}, "")
+	assert.Empty(t, r.Checks, "nothing to check when every touchpad is grabbed")
+
+	modes := map[string]touchpad.Suppression{"ELAN1200:00 Touchpad": touchpad.SuppressClicks}
+	checkUinput(r, modes, "")
+	assert.Equal(t, Warn, lastCheck(r).Status)
+	assert.Equal(t, []string{"ELAN1200:00 Touchpad: clicks"}, lastCheck(r).Details)
+
+	require.NoError(t, os.WriteFile(uinputPath, nil, 0600))
+	checkUinput(r, modes, "")
+	assert.Equal(t, Pass, lastCheck(r).Status)
+
+	// After dropping root the helper opens it, whoever runs doctor
+	checkUinput(r, modes, "palm-reject")
+	assert.Equal(t, Warn, lastCheck(r).Status, "a regular file is no uinput node")
+	uinputPath = os.DevNull
+	checkUinput(r, modes, "palm-reject")
+	assert.Equal(t, Pass, lastCheck(r).Status)
+	assert.Contains(t, lastCheck(r).Message, "privilege helper")
+}
+
+func TestReport_Output(t *testing.T) {
+	r := Report{}
+	r.add("config", Pass, "ok")
+	r.add("grab /dev/input/event5", Fail, "busy", "pid 1 (x)")
+	assert.True(t, r.Failed())
+
+	var text bytes.Buffer
+	require.NoError(t, r.WriteText(&text))
+	assert.Equal(t, "[PASS] config: ok\n[FAIL] grab /dev/input/event5: busy\n       pid 1 (x)\n", text.String())
+
+	var out struct {
+		OK     bool    `json:"ok"`
+		Checks []Check `json:"checks"`
+	}
+	var js bytes.Buffer
+	require.NoError(t, r.WriteJSON(&js))
+	require.NoError(t, json.Unmarshal(js.Bytes(), &out))
+	assert.False(t, out.OK)
+	assert.Len(t, out.Checks, 2)
+}
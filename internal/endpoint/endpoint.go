@@ -0,0 +1,141 @@
+// Package endpoint creates the daemon's named pipe and control socket
+// without trusting what is already on disk. Both live in a root-owned
+// runtime directory; an existing path is only replaced if it is of the
+// expected type and owned by the daemon, never if it is a symlink, and
+// ownership and mode are set explicitly instead of relying on the umask.
+package endpoint
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// DefaultDir is the runtime directory holding the pipe and socket.
+const DefaultDir = "/run/palm-reject"
+
+// Permissions are the owner, group and mode of an endpoint. A UID or GID
+// of -1 keeps the daemon's own.
+type Permissions struct {
+	UID  int
+	GID  int
+	Mode os.FileMode
+}
+
+// Private is owner-only access by the daemon's user.
+var Private = Permissions{UID: -1, GID: -1, Mode: 0600}
+
+// Prepare makes path ready to be created: its directory is created if
+// missing and checked not to be writable by others, and a leftover
+// endpoint of the given type (os.ModeNamedPipe or os.ModeSocket) is removed.
+// Anything else at path is an error.
+func (p Permissions) Prepare(path string, kind os.FileMode) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("%s: path must be absolute", path)
+	}
+	if err := prepareDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := p.checkExisting(path, kind); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return os.Remove(path)
+}
+
+// Apply sets the owner, group and mode of a freshly created endpoint.
+func (p Permissions) Apply(path string) error {
+	if p.UID != -1 || p.GID != -1 {
+		if err := os.Lchown(path, p.UID, p.GID); err != nil {
+			return fmt.Errorf("failed to chown %s: %w", path, err)
+		}
+	}
+	if err := os.Chmod(path, p.Mode); err != nil {
+		return fmt.Errorf("failed to chmod %s: %w", path, err)
+	}
+	return nil
+}
+
+// Remove removes the endpoint on shutdown if it is still the daemon's.
+// Anything else at path, or an endpoint the daemon can no longer remove
+// after dropping root, is left for the next Prepare.
+func (p Permissions) Remove(path string, kind os.FileMode) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if info.Mode().Type() != kind || owner(info) != os.Geteuid() {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrPermission) {
+		return err
+	}
+	return nil
+}
+
+// checkExisting returns fs.ErrNotExist if nothing is at path, nil if an
+// endpoint the daemon may replace is, and a descriptive error otherwise.
+func (p Permissions) checkExisting(path string, kind os.FileMode) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return fmt.Errorf("%s is a symlink; refusing to use it", path)
+	}
+	if info.Mode().Type() != kind {
+		return fmt.Errorf("%s exists and is not a %s", path, kindName(kind))
+	}
+	if uid := owner(info); uid != os.Geteuid() && uid != p.UID {
+		return fmt.Errorf("%s is owned by uid %d; refusing to replace it", path, uid)
+	}
+	return nil
+}
+
+// prepareDir creates dir (mode 0755) if missing and refuses directories
+// that are symlinks, owned by another user than root or the daemon, or
+// writable by others without the sticky bit.
+func prepareDir(dir string) error {
+	if err := os.Mkdir(dir, 0755); err != nil && !errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	if uid := owner(info); uid != 0 && uid != os.Geteuid() {
+		return fmt.Errorf("%s is owned by uid %d", dir, uid)
+	}
+	if info.Mode().Perm()&0022 != 0 && info.Mode()&os.ModeSticky == 0 {
+		return fmt.Errorf("%s is writable by other users", dir)
+	}
+	return nil
+}
+
+func owner(info os.FileInfo) int {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return int(st.Uid)
+	}
+	return -1
+}
+
+func kindName(kind os.FileMode) string {
+	switch kind {
+	case os.ModeNamedPipe:
+		return "named pipe"
+	case os.ModeSocket:
+		return "socket"
+	}
+	return kind.String()
+}
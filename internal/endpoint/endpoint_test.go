@@ -0,0 +1,61 @@
+package endpoint
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepare(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "run")
+	path := filepath.Join(dir, "daemon.pipe")
+
+	require.NoError(t, Private.Prepare(path, os.ModeNamedPipe))
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	// A leftover pipe of our own is replaced
+	require.NoError(t, syscall.Mkfifo(path, 0600))
+	require.NoError(t, Private.Prepare(path, os.ModeNamedPipe))
+	assert.NoFileExists(t, path)
+}
+
+func TestPrepare_Refuses(t *testing.T) {
+	dir := t.TempDir()
+
+	link := filepath.Join(dir, "link")
+	require.NoError(t, os.Symlink("/etc/passwd", link))
+	assert.ErrorContains(t, Private.Prepare(link, os.ModeNamedPipe), "is a symlink")
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+	assert.ErrorContains(t, Private.Prepare(file, os.ModeSocket), "is not a socket")
+	assert.FileExists(t, file)
+
+	open := filepath.Join(dir, "open")
+	require.NoError(t, os.Mkdir(open, 0777))
+	require.NoError(t, os.Chmod(open, 0777))
+	assert.ErrorContains(t, Private.Prepare(filepath.Join(open, "daemon.sock"), os.ModeSocket), "writable by other users")
+
+	assert.ErrorContains(t, Private.Prepare("daemon.sock", os.ModeSocket), "must be absolute")
+}
+
+func TestApplyAndRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.pipe")
+	require.NoError(t, syscall.Mkfifo(path, 0600))
+
+	perm := Permissions{UID: -1, GID: os.Getgid(), Mode: 0620}
+	require.NoError(t, perm.Apply(path))
+	info, err := os.Lstat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0620), info.Mode().Perm())
+
+	require.NoError(t, perm.Remove(path, os.ModeNamedPipe))
+	assert.NoFileExists(t, path)
+	assert.NoError(t, perm.Remove(path, os.ModeNamedPipe), "already gone")
+}
@@ -1,51 +1,435 @@
 package events
 
 import (
+    "fmt"
     "sync"
+    "sync/atomic"
+    "time"
+
     "github.com/rs/zerolog"
+
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/metrics"
+)
+
+const (
+    // DefaultBufferSize is the number of events queued per subscriber.
+    DefaultBufferSize = 100
+    // DefaultBlockTimeout bounds how long Publish waits on a BlockWithTimeout subscriber.
+    DefaultBlockTimeout = 100 * time.Millisecond
+    // stallTimeout is how long a subscriber may leave an event untaken
+    // before the bus reports it stuck (see Responsive).
+    stallTimeout = 10 * time.Second
+)
+
+// DeliveryPolicy decides what happens when a subscriber's buffer is full.
+// Critical events (see SystemEvent.IsCritical) are never dropped regardless
+// of the policy: they evict the oldest non-critical event instead.
+type DeliveryPolicy int
+
+const (
+    // DropNewest discards the event being published (the historical behaviour).
+    DropNewest DeliveryPolicy = iota
+    // DropOldest discards the oldest queued event to make room.
+    DropOldest
+    // BlockWithTimeout makes Publish wait for room, up to the subscriber's timeout.
+    BlockWithTimeout
+    // CoalesceLatest keeps only the latest event of each state group
+    // (e.g. TouchpadDisable/TouchpadEnable) and drops the newest otherwise.
+    // A queued critical event is never superseded.
+    CoalesceLatest
 )
 
+// String returns a human‑readable name for the delivery policy.
+func (p DeliveryPolicy) String() string {
+    switch p {
+    case DropNewest:
+        return "drop-newest"
+    case DropOldest:
+        return "drop-oldest"
+    case BlockWithTimeout:
+        return "block"
+    case CoalesceLatest:
+        return "coalesce"
+    default:
+        return "unknown"
+    }
+}
+
+// SubscribeOption configures a subscription.
+type SubscribeOption func(*subscriber)
+
+// WithName labels the subscriber in logs and statistics.
+func WithName(name string) SubscribeOption {
+    return func(s *subscriber) { s.name = name }
+}
+
+// WithPolicy sets the delivery policy used when the subscriber falls behind.
+func WithPolicy(policy DeliveryPolicy) SubscribeOption {
+    return func(s *subscriber) { s.policy = policy }
+}
+
+// WithBufferSize sets the number of events queued for the subscriber.
+func WithBufferSize(size int) SubscribeOption {
+    return func(s *subscriber) {
+        if size > 0 {
+            s.capacity = size
+        }
+    }
+}
+
+// WithBlockTimeout sets how long Publish may wait under BlockWithTimeout.
+func WithBlockTimeout(timeout time.Duration) SubscribeOption {
+    return func(s *subscriber) {
+        if timeout > 0 {
+            s.blockTimeout = timeout
+        }
+    }
+}
+
+// SubscriberStats is a snapshot of the delivery counters of one subscriber.
+type SubscriberStats struct {
+    Name      string `json:"name"`
+    Policy    string `json:"policy"`
+    Capacity  int    `json:"capacity"`
+    Queued    int    `json:"queued"`
+    Delivered uint64 `json:"delivered"`
+    Dropped   uint64 `json:"dropped"`
+    Delayed   uint64 `json:"delayed"`
+    Coalesced uint64 `json:"coalesced"`
+}
+
+// BusStats is a snapshot of the delivery counters of the whole bus.
+type BusStats struct {
+    Published   uint64            `json:"published"`
+    Dropped     uint64            `json:"dropped"`
+    Delayed     uint64            `json:"delayed"`
+    Subscribers []SubscriberStats `json:"subscribers"`
+}
+
 // SystemEventBus implements a broadcast pattern for system events.
+// Every subscriber owns a bounded queue drained by its own goroutine, so a
+// slow subscriber only affects itself according to its DeliveryPolicy.
 type SystemEventBus struct {
     mu          sync.RWMutex
-    subscribers []chan SystemEvent
+    subscribers []*subscriber
     logger      zerolog.Logger
+    metrics     *metrics.Metrics
+
+    published atomic.Uint64
+    dropped   atomic.Uint64
+    delayed   atomic.Uint64
 }
 
 func NewSystemEventBus(logger zerolog.Logger) *SystemEventBus {
     return &SystemEventBus{
-        subscribers: make([]chan SystemEvent, 0),
+        subscribers: make([]*subscriber, 0),
         logger:      logger,
     }
 }
 
 func (b *SystemEventBus) Publish(event SystemEvent) {
     b.mu.RLock()
-    defer b.mu.RUnlock()
-    b.logger.Debug().Str("event", event.String()).Int("subscribers", len(b.subscribers)).Msg("Publishing SystemEvent")
-    for i, sub := range b.subscribers {
-        select {
-        case sub <- event:
-        default:
-            b.logger.Warn().Str("event", event.String()).Int("subscriber", i).Msg("SystemEventBus subscriber buffer full, dropping event")
+    subs := make([]*subscriber, len(b.subscribers))
+    copy(subs, b.subscribers)
+    b.mu.RUnlock()
+
+    b.published.Add(1)
+    b.logger.Debug().Str("event", event.String()).Int("subscribers", len(subs)).Msg("Publishing SystemEvent")
+    for _, sub := range subs {
+        res := sub.offer(event)
+        if res.delayed {
+            b.delayed.Add(1)
+            b.metrics.ObserveBusDelay(sub.name)
+        }
+        if res.evicted != SystemEventNone {
+            b.dropped.Add(1)
+            b.metrics.ObserveBusDrop(sub.name)
+            b.logger.Warn().
+                Str("event", res.evicted.String()).
+                Str("subscriber", sub.name).
+                Str("policy", sub.policy.String()).
+                Msg("SystemEventBus subscriber buffer full, dropping event")
         }
     }
 }
 
-func (b *SystemEventBus) Subscribe() <-chan SystemEvent {
-    ch := make(chan SystemEvent, 100)
+// SetMetrics enables drop/delay instrumentation. Call before publishing.
+func (b *SystemEventBus) SetMetrics(m *metrics.Metrics) {
+    b.metrics = m
+}
+
+// Subscribe registers a new subscriber and returns its event channel.
+// Without options the subscriber gets a 100-slot DropNewest queue.
+func (b *SystemEventBus) Subscribe(opts ...SubscribeOption) <-chan SystemEvent {
+    sub := &subscriber{
+        policy:       DropNewest,
+        capacity:     DefaultBufferSize,
+        blockTimeout: DefaultBlockTimeout,
+        notify:       make(chan struct{}, 1),
+        space:        make(chan struct{}, 1),
+        done:         make(chan struct{}),
+        out:          make(chan SystemEvent),
+    }
+    for _, opt := range opts {
+        opt(sub)
+    }
+
     b.mu.Lock()
-    b.subscribers = append(b.subscribers, ch)
+    if sub.name == "" {
+        sub.name = fmt.Sprintf("subscriber-%d", len(b.subscribers))
+    }
+    b.subscribers = append(b.subscribers, sub)
+    total := len(b.subscribers)
     b.mu.Unlock()
-    b.logger.Debug().Int("total_subscribers", len(b.subscribers)).Msg("New subscriber")
-    return ch
+
+    go sub.run()
+
+    b.logger.Debug().
+        Str("subscriber", sub.name).
+        Str("policy", sub.policy.String()).
+        Int("total_subscribers", total).
+        Msg("New subscriber")
+    return sub.out
+}
+
+// Stats returns a snapshot of the bus delivery counters.
+func (b *SystemEventBus) Stats() BusStats {
+    b.mu.RLock()
+    subs := make([]*subscriber, len(b.subscribers))
+    copy(subs, b.subscribers)
+    b.mu.RUnlock()
+
+    stats := BusStats{
+        Published:   b.published.Load(),
+        Dropped:     b.dropped.Load(),
+        Delayed:     b.delayed.Load(),
+        Subscribers: make([]SubscriberStats, 0, len(subs)),
+    }
+    for _, sub := range subs {
+        stats.Subscribers = append(stats.Subscribers, sub.stats())
+    }
+    return stats
+}
+
+// Responsive reports an error when a subscriber has left an event untaken
+// for longer than stallTimeout, i.e. its event loop is stuck. A subscriber
+// with nothing to deliver is responsive, however long it has been idle.
+func (b *SystemEventBus) Responsive() error {
+    b.mu.RLock()
+    subs := make([]*subscriber, len(b.subscribers))
+    copy(subs, b.subscribers)
+    b.mu.RUnlock()
+
+    for _, sub := range subs {
+        sub.mu.Lock()
+        since := sub.sendingSince
+        sub.mu.Unlock()
+        if d := time.Since(since); !since.IsZero() && d > stallTimeout {
+            return fmt.Errorf("subscriber %s has not taken an event for %s", sub.name, d.Round(time.Second))
+        }
+    }
+    return nil
 }
 
 func (b *SystemEventBus) Close() {
     b.mu.Lock()
     defer b.mu.Unlock()
     for _, sub := range b.subscribers {
-        close(sub)
+        close(sub.done)
     }
     b.subscribers = nil
 }
+
+// subscriber is a bounded, policy-driven queue in front of an unbuffered channel.
+type subscriber struct {
+    name         string
+    policy       DeliveryPolicy
+    capacity     int
+    blockTimeout time.Duration
+
+    mu        sync.Mutex
+    queue     []SystemEvent
+    delivered uint64
+    dropped   uint64
+    delayed   uint64
+    coalesced uint64
+    // sendingSince is when the pump started waiting for the subscriber to
+    // take an event; zero while it is not waiting
+    sendingSince time.Time
+
+    notify chan struct{} // signalled when the queue becomes non-empty
+    space  chan struct{} // signalled when the pump frees a slot
+    done   chan struct{}
+    out    chan SystemEvent
+}
+
+// offerResult describes what happened to a published event.
+type offerResult struct {
+    delayed bool
+    evicted SystemEvent // the event that was dropped, if any
+}
+
+// offer enqueues event according to the subscriber's policy.
+func (s *subscriber) offer(event SystemEvent) offerResult {
+    var res offerResult
+    var deadline *time.Timer
+
+    for {
+        s.mu.Lock()
+        if s.policy == CoalesceLatest {
+            s.coalesceLocked(event)
+        }
+
+        if len(s.queue) < s.capacity {
+            s.queue = append(s.queue, event)
+            s.mu.Unlock()
+            s.signal()
+            break
+        }
+
+        if event.IsCritical() {
+            if i := s.oldestNonCriticalLocked(); i >= 0 {
+                res.evicted = s.removeLocked(i)
+                s.dropped++
+            }
+            // An all-critical queue is allowed to overflow.
+            s.queue = append(s.queue, event)
+            s.mu.Unlock()
+            s.signal()
+            break
+        }
+
+        if s.policy == DropOldest {
+            if i := s.oldestNonCriticalLocked(); i >= 0 {
+                res.evicted = s.removeLocked(i)
+                s.dropped++
+                s.queue = append(s.queue, event)
+                s.mu.Unlock()
+                s.signal()
+                break
+            }
+        }
+
+        if s.policy != BlockWithTimeout {
+            res.evicted = event
+            s.dropped++
+            s.mu.Unlock()
+            break
+        }
+
+        // BlockWithTimeout: wait for the pump to free a slot.
+        if !res.delayed {
+            res.delayed = true
+            s.delayed++
+            deadline = time.NewTimer(s.blockTimeout)
+        }
+        s.mu.Unlock()
+
+        select {
+        case <-s.space:
+            continue
+        case <-s.done:
+            deadline.Stop()
+            return res
+        case <-deadline.C:
+            s.mu.Lock()
+            s.dropped++
+            s.mu.Unlock()
+            res.evicted = event
+            return res
+        }
+    }
+
+    if deadline != nil {
+        deadline.Stop()
+    }
+    return res
+}
+
+// coalesceLocked removes a queued event that the new event supersedes.
+// Critical events stay queued and are delivered in order.
+func (s *subscriber) coalesceLocked(event SystemEvent) {
+    group := event.stateGroup()
+    if group == 0 {
+        return
+    }
+    for i, queued := range s.queue {
+        if queued.stateGroup() == group && !queued.IsCritical() {
+            s.removeLocked(i)
+            s.coalesced++
+            return
+        }
+    }
+}
+
+func (s *subscriber) oldestNonCriticalLocked() int {
+    for i, queued := range s.queue {
+        if !queued.IsCritical() {
+            return i
+        }
+    }
+    return -1
+}
+
+func (s *subscriber) removeLocked(i int) SystemEvent {
+    event := s.queue[i]
+    s.queue = append(s.queue[:i], s.queue[i+1:]...)
+    return event
+}
+
+func (s *subscriber) signal() {
+    select {
+    case s.notify <- struct{}{}:
+    default:
+    }
+}
+
+// run delivers queued events to the subscriber channel until the bus closes.
+func (s *subscriber) run() {
+    defer close(s.out)
+    for {
+        s.mu.Lock()
+        if len(s.queue) == 0 {
+            s.mu.Unlock()
+            select {
+            case <-s.notify:
+                continue
+            case <-s.done:
+                return
+            }
+        }
+        event := s.removeLocked(0)
+        s.sendingSince = time.Now()
+        s.mu.Unlock()
+
+        select {
+        case s.space <- struct{}{}:
+        default:
+        }
+
+        select {
+        case s.out <- event:
+            s.mu.Lock()
+            s.delivered++
+            s.sendingSince = time.Time{}
+            s.mu.Unlock()
+        case <-s.done:
+            return
+        }
+    }
+}
+
+func (s *subscriber) stats() SubscriberStats {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return SubscriberStats{
+        Name:      s.name,
+        Policy:    s.policy.String(),
+        Capacity:  s.capacity,
+        Queued:    len(s.queue),
+        Delivered: s.delivered,
+        Dropped:   s.dropped,
+        Delayed:   s.delayed,
+        Coalesced: s.coalesced,
+    }
+}
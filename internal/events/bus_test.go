@@ -19,6 +19,7 @@ func TestSystemEvent_String(t *testing.T) {
 		{"LaptopSuspend", LaptopSuspend, "LaptopSuspend"},
 		{"LaptopResume", LaptopResume, "LaptopResume"},
 		{"BacklightToggle", BacklightToggle, "BacklightToggle"},
+		{"GamingModeToggle", GamingModeToggle, "GamingModeToggle"},
 	}
 
 	for _, tt := range tests {
@@ -90,4 +91,196 @@ done:
 	assert.True(t, received1 || received2, "At least one subscriber should receive the event")
 
 	bus.Close()
-}
\ No newline at end of file
+}
+
+// stalledSubscriber subscribes and publishes one event that the delivery
+// goroutine picks up and holds, so the queue starts empty and stays put
+// until the test reads from the channel.
+func stalledSubscriber(t *testing.T, bus *SystemEventBus, opts ...SubscribeOption) <-chan SystemEvent {
+	t.Helper()
+	sub := bus.Subscribe(opts...)
+	bus.Publish(SecondaryDisplayToggle)
+	assert.Eventually(t, func() bool {
+		return bus.Stats().Subscribers[0].Queued == 0
+	}, time.Second, time.Millisecond)
+	return sub
+}
+
+func receiveN(t *testing.T, sub <-chan SystemEvent, n int) []SystemEvent {
+	t.Helper()
+	var got []SystemEvent
+	for i := 0; i < n; i++ {
+		select {
+		case ev := <-sub:
+			got = append(got, ev)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for event %d", i)
+		}
+	}
+	return got
+}
+
+func TestSystemEventBus_DeliveryPolicies(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    DeliveryPolicy
+		publish   []SystemEvent
+		expected  []SystemEvent
+		dropped   uint64
+		coalesced uint64
+	}{
+		{
+			name:     "drop newest",
+			policy:   DropNewest,
+			publish:  []SystemEvent{BacklightLow, MicMuteLedOn, MicMuteLedToggle},
+			expected: []SystemEvent{BacklightLow, MicMuteLedOn},
+			dropped:  1,
+		},
+		{
+			name:     "drop oldest",
+			policy:   DropOldest,
+			publish:  []SystemEvent{BacklightLow, MicMuteLedOn, MicMuteLedToggle},
+			expected: []SystemEvent{MicMuteLedOn, MicMuteLedToggle},
+			dropped:  1,
+		},
+		{
+			name:      "coalesce latest state",
+			policy:    CoalesceLatest,
+			publish:   []SystemEvent{MicMuteLedOn, BacklightLow, MicMuteLedOff},
+			expected:  []SystemEvent{BacklightLow, MicMuteLedOff},
+			coalesced: 1,
+		},
+		{
+			name:     "coalescing keeps suspend and resume",
+			policy:   CoalesceLatest,
+			publish:  []SystemEvent{LaptopSuspend, LaptopResume},
+			expected: []SystemEvent{LaptopSuspend, LaptopResume},
+		},
+		{
+			name:     "coalescing keeps a queued enable",
+			policy:   CoalesceLatest,
+			publish:  []SystemEvent{TouchpadEnable, TouchpadDisable},
+			expected: []SystemEvent{TouchpadEnable, TouchpadDisable},
+		},
+		{
+			name:      "enable supersedes a queued disable",
+			policy:    CoalesceLatest,
+			publish:   []SystemEvent{TouchpadDisable, TouchpadEnable},
+			expected:  []SystemEvent{TouchpadEnable},
+			coalesced: 1,
+		},
+		{
+			name:     "critical event evicts the oldest ordinary one",
+			policy:   DropNewest,
+			publish:  []SystemEvent{BacklightLow, MicMuteLedOn, LaptopSuspend},
+			expected: []SystemEvent{MicMuteLedOn, LaptopSuspend},
+			dropped:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := NewSystemEventBus(zerolog.Nop())
+			defer bus.Close()
+			sub := stalledSubscriber(t, bus, WithPolicy(tt.policy), WithBufferSize(2))
+
+			for _, ev := range tt.publish {
+				bus.Publish(ev)
+			}
+
+			got := receiveN(t, sub, len(tt.expected)+1)
+			assert.Equal(t, SecondaryDisplayToggle, got[0])
+			assert.Equal(t, tt.expected, got[1:])
+
+			stats := bus.Stats()
+			assert.Equal(t, tt.dropped, stats.Dropped)
+			assert.Equal(t, tt.dropped, stats.Subscribers[0].Dropped)
+			assert.Equal(t, tt.coalesced, stats.Subscribers[0].Coalesced)
+			assert.Equal(t, tt.policy.String(), stats.Subscribers[0].Policy)
+		})
+	}
+}
+
+func TestSystemEventBus_CriticalKeepsOrder(t *testing.T) {
+	bus := NewSystemEventBus(zerolog.Nop())
+	defer bus.Close()
+	sub := stalledSubscriber(t, bus, WithPolicy(DropNewest), WithBufferSize(4))
+
+	// A suspend must not overtake the disable before it, or the touchpad
+	// would stay grabbed across sleep
+	bus.Publish(TouchpadDisable)
+	bus.Publish(LaptopSuspend)
+	bus.Publish(TouchpadToggle)
+	bus.Publish(TouchpadEnable)
+
+	got := receiveN(t, sub, 5)
+	assert.Equal(t, []SystemEvent{TouchpadDisable, LaptopSuspend, TouchpadToggle, TouchpadEnable}, got[1:])
+	assert.Equal(t, uint64(0), bus.Stats().Dropped)
+}
+
+func TestSystemEventBus_BlockWithTimeout(t *testing.T) {
+	t.Run("waits for the subscriber", func(t *testing.T) {
+		bus := NewSystemEventBus(zerolog.Nop())
+		defer bus.Close()
+		sub := stalledSubscriber(t, bus,
+			WithPolicy(BlockWithTimeout), WithBufferSize(1), WithBlockTimeout(time.Second))
+
+		bus.Publish(BacklightLow)
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			<-sub
+		}()
+		bus.Publish(BacklightHigh)
+
+		stats := bus.Stats()
+		assert.Equal(t, uint64(1), stats.Delayed)
+		assert.Equal(t, uint64(0), stats.Dropped)
+		assert.Equal(t, []SystemEvent{BacklightLow, BacklightHigh}, receiveN(t, sub, 2))
+	})
+
+	t.Run("drops after the timeout", func(t *testing.T) {
+		bus := NewSystemEventBus(zerolog.Nop())
+		defer bus.Close()
+		stalledSubscriber(t, bus,
+			WithPolicy(BlockWithTimeout), WithBufferSize(1), WithBlockTimeout(10*time.Millisecond))
+
+		bus.Publish(BacklightLow)
+		bus.Publish(BacklightHigh)
+
+		stats := bus.Stats()
+		assert.Equal(t, uint64(1), stats.Delayed)
+		assert.Equal(t, uint64(1), stats.Dropped)
+	})
+}
+
+func TestSystemEventBus_CloseClosesChannels(t *testing.T) {
+	bus := NewSystemEventBus(zerolog.Nop())
+	sub := bus.Subscribe(WithName("closer"))
+	assert.Equal(t, "closer", bus.Stats().Subscribers[0].Name)
+
+	bus.Close()
+	select {
+	case _, ok := <-sub:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("subscriber channel not closed")
+	}
+}
+
+func TestSystemEventBus_Responsive(t *testing.T) {
+	bus := NewSystemEventBus(zerolog.Nop())
+	defer bus.Close()
+	assert.NoError(t, bus.Responsive())
+
+	sub := stalledSubscriber(t, bus, WithName("stuck"))
+	assert.NoError(t, bus.Responsive(), "a short wait is fine")
+
+	bus.subscribers[0].mu.Lock()
+	bus.subscribers[0].sendingSince = time.Now().Add(-2 * stallTimeout)
+	bus.subscribers[0].mu.Unlock()
+	assert.ErrorContains(t, bus.Responsive(), "subscriber stuck has not taken an event")
+
+	<-sub
+	assert.Eventually(t, func() bool { return bus.Responsive() == nil }, time.Second, time.Millisecond,
+		"an idle subscriber is responsive")
+}
@@ -0,0 +1,43 @@
+package events
+
+import (
+	"sort"
+	"strings"
+)
+
+// commandEvents maps the textual commands accepted by the pipe and control
+// interfaces to the SystemEvent they publish.
+var commandEvents = map[string]SystemEvent{
+	"touchpad_disable": TouchpadDisable,
+	"touchpad_enable":  TouchpadEnable,
+	"touchpad_toggle":  TouchpadToggle,
+	"micmute_on":       MicMuteLedOn,
+	"micmute_off":      MicMuteLedOff,
+	"micmute_toggle":   MicMuteLedToggle,
+	"backlight_off":    BacklightOff,
+	"backlight_low":    BacklightLow,
+	"backlight_medium": BacklightMedium,
+	"backlight_high":   BacklightHigh,
+	"backlight_toggle": BacklightToggle,
+	"display_toggle":   SecondaryDisplayToggle,
+	"gaming_on":        GamingModeOn,
+	"gaming_off":       GamingModeOff,
+	"gaming_toggle":    GamingModeToggle,
+}
+
+// CommandEvent returns the SystemEvent published for a textual command.
+// Commands are matched case-insensitively.
+func CommandEvent(cmd string) (SystemEvent, bool) {
+	event, ok := commandEvents[strings.ToLower(strings.TrimSpace(cmd))]
+	return event, ok
+}
+
+// Commands returns the sorted names of all event commands.
+func Commands() []string {
+	names := make([]string, 0, len(commandEvents))
+	for name := range commandEvents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
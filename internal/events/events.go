@@ -21,6 +21,9 @@ const (
     TouchpadDisable
     TouchpadEnable
     TouchpadToggle
+    GamingModeOn
+    GamingModeOff
+    GamingModeToggle
 )
 
 // String returns a human‑readable name for the system event.
@@ -60,7 +63,47 @@ func (s SystemEvent) String() string {
         return "TouchpadEnable"
     case TouchpadToggle:
         return "TouchpadToggle"
+    case GamingModeOn:
+        return "GamingModeOn"
+    case GamingModeOff:
+        return "GamingModeOff"
+    case GamingModeToggle:
+        return "GamingModeToggle"
     default:
         return "Unknown"
     }
 }
+
+// IsCritical reports whether the event must never be dropped by the bus.
+// Losing a suspend/resume or an explicit touchpad enable could leave the
+// touchpad grabbed, so when a queue overflows an ordinary event is evicted
+// instead. Delivery stays in publish order either way.
+func (s SystemEvent) IsCritical() bool {
+    switch s {
+    case LaptopSuspend, LaptopResume, TouchpadEnable:
+        return true
+    default:
+        return false
+    }
+}
+
+// stateGroup returns a non-zero key for events that describe an absolute
+// state, so that a newer event of the same group supersedes an older one.
+// Toggles are not idempotent and therefore never coalesce, and neither do
+// suspend and resume: a consumer must see both to release the touchpad.
+func (s SystemEvent) stateGroup() int {
+    switch s {
+    case MicMuteLedOn, MicMuteLedOff:
+        return 2
+    case BacklightOff, BacklightLow, BacklightMedium, BacklightHigh:
+        return 3
+    case USBKeyboardAttached, USBKeyboardDetached:
+        return 4
+    case TouchpadDisable, TouchpadEnable:
+        return 5
+    case GamingModeOn, GamingModeOff:
+        return 6
+    default:
+        return 0
+    }
+}
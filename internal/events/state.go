@@ -0,0 +1,155 @@
+package events
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// SuppressionReason explains why the touchpad was suppressed or restored.
+type SuppressionReason string
+
+const (
+	// ReasonTyping is keyboard activity and its cooldown.
+	ReasonTyping SuppressionReason = "typing"
+	// ReasonManual is a pipe or control command.
+	ReasonManual SuppressionReason = "manual"
+	// ReasonSuspend is the laptop going to sleep.
+	ReasonSuspend SuppressionReason = "suspend"
+	// ReasonShutdown is the daemon stopping.
+	ReasonShutdown SuppressionReason = "shutdown"
+	// ReasonSession is the user's login session becoming inactive.
+	ReasonSession SuppressionReason = "session"
+	// ReasonTouch is a deliberate touch ending a typing suppression early.
+	ReasonTouch SuppressionReason = "touch"
+)
+
+// TouchpadState is the touchpad suppression state reported to observers.
+// It is published on the StateFeed only, never on the SystemEventBus, whose
+// events are commands and notifications for consumers.
+type TouchpadState int
+
+const (
+	// TouchpadRestored means the touchpad is enabled.
+	TouchpadRestored TouchpadState = iota
+	// TouchpadSuppressed means the touchpad is grabbed.
+	TouchpadSuppressed
+)
+
+// String returns the state name used in the JSON encoding.
+func (s TouchpadState) String() string {
+	switch s {
+	case TouchpadRestored:
+		return "TouchpadRestored"
+	case TouchpadSuppressed:
+		return "TouchpadSuppressed"
+	default:
+		return "Unknown"
+	}
+}
+
+// TouchpadStateEvent describes a change of the touchpad suppression state.
+// Event is TouchpadSuppressed or TouchpadRestored; Duration is how long the
+// touchpad was suppressed and is only set for TouchpadRestored.
+type TouchpadStateEvent struct {
+	Event    TouchpadState
+	Reason   SuppressionReason
+	Time     time.Time
+	Duration time.Duration
+}
+
+// Suppressed reports whether the event leaves the touchpad suppressed.
+func (e TouchpadStateEvent) Suppressed() bool {
+	return e.Event == TouchpadSuppressed
+}
+
+// MarshalJSON encodes the event as a flat object suitable for status bars.
+func (e TouchpadStateEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Event      string            `json:"event"`
+		Suppressed bool              `json:"suppressed"`
+		Reason     SuppressionReason `json:"reason,omitempty"`
+		Time       time.Time         `json:"time"`
+		DurationMs int64             `json:"duration_ms,omitempty"`
+	}{
+		Event:      e.Event.String(),
+		Suppressed: e.Suppressed(),
+		Reason:     e.Reason,
+		Time:       e.Time,
+		DurationMs: e.Duration.Milliseconds(),
+	})
+}
+
+// StateFeed broadcasts TouchpadStateEvents to subscribers that come and go,
+// such as "ctl watch" clients. A subscriber that falls behind loses its
+// oldest events, since only the latest state matters to observers.
+type StateFeed struct {
+	mu     sync.Mutex
+	subs   map[chan TouchpadStateEvent]struct{}
+	logger zerolog.Logger
+}
+
+// NewStateFeed creates an empty state feed.
+func NewStateFeed(logger zerolog.Logger) *StateFeed {
+	return &StateFeed{
+		subs:   make(map[chan TouchpadStateEvent]struct{}),
+		logger: logger,
+	}
+}
+
+// Publish delivers the event to every subscriber without blocking.
+func (f *StateFeed) Publish(event TouchpadStateEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ch := range f.subs {
+		for {
+			select {
+			case ch <- event:
+			default:
+				select {
+				case <-ch:
+					f.logger.Debug().Str("event", event.Event.String()).Msg("State feed subscriber behind, dropping oldest event")
+				default:
+				}
+				continue
+			}
+			break
+		}
+	}
+}
+
+// Subscribe returns a channel of state events and a function that
+// unsubscribes and closes the channel.
+func (f *StateFeed) Subscribe(buffer int) (<-chan TouchpadStateEvent, func()) {
+	if buffer <= 0 {
+		buffer = 16
+	}
+	ch := make(chan TouchpadStateEvent, buffer)
+	f.mu.Lock()
+	f.subs[ch] = struct{}{}
+	f.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if _, ok := f.subs[ch]; ok {
+				delete(f.subs, ch)
+				close(ch)
+			}
+		})
+	}
+}
+
+// Close closes all subscriber channels.
+func (f *StateFeed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ch := range f.subs {
+		close(ch)
+	}
+	f.subs = make(map[chan TouchpadStateEvent]struct{})
+}
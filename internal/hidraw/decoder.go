@@ -0,0 +1,26 @@
+package hidraw
+
+import "github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+
+// fnReportID is the report ID of ASUS vendor hotkey reports.
+const fnReportID = 0x5a
+
+// fnKeys maps the key code in byte 1 of a hotkey report to the event it
+// triggers. Codes were captured from the UX8406 keyboard over USB and
+// Bluetooth; they match the vendor codes handled by the kernel's hid-asus.
+var fnKeys = map[byte]events.SystemEvent{
+	0x7c: events.MicMuteLedToggle,       // F9 mic mute
+	0xc7: events.BacklightToggle,        // F4 keyboard backlight cycle
+	0x6a: events.SecondaryDisplayToggle, // F8 screen swap
+	0x6b: events.TouchpadToggle,         // F10 touchpad toggle
+}
+
+// Decode returns the event for a raw hotkey report. Key releases (a zero
+// key code), other report IDs and unknown codes yield false.
+func Decode(report []byte) (events.SystemEvent, bool) {
+	if len(report) < 2 || report[0] != fnReportID || report[1] == 0 {
+		return events.SystemEventNone, false
+	}
+	event, ok := fnKeys[report[1]]
+	return event, ok
+}
@@ -0,0 +1,120 @@
+// Package hidraw reads the ASUS vendor-defined HID interface of the Zenbook
+// Duo keyboard. The Fn hotkeys (mic mute, backlight, screen swap, touchpad
+// toggle) are not delivered as evdev key events but as vendor reports on
+// this interface.
+package hidraw
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// hidrawDevDir is the directory containing hidraw nodes
+	hidrawDevDir = "/dev"
+	// sysClassHidraw is the sysfs path for hidraw device information
+	sysClassHidraw = "/sys/class/hidraw"
+)
+
+// asusVendorID is the USB vendor ID of ASUSTek.
+const asusVendorID = 0x0b05
+
+// vendorUsagePage is the "Usage Page (Vendor 0xFF31)" item that starts the
+// ASUS hotkey collection in the report descriptor.
+var vendorUsagePage = []byte{0x06, 0x31, 0xff}
+
+// Device is a hidraw node carrying ASUS vendor reports.
+type Device struct {
+	// Path is the device node (e.g. /dev/hidraw2)
+	Path string
+	// Name is HID_NAME from the HID device's uevent
+	Name string
+	// Bus is the HID bus type (0x03 USB, 0x05 Bluetooth)
+	Bus uint16
+	// Vendor and Product identify the keyboard
+	Vendor, Product uint32
+}
+
+// FindFnKeyDevices returns every hidraw node whose report descriptor
+// declares the ASUS vendor usage page, in numeric order. With the keyboard
+// docked and paired over Bluetooth there can be more than one.
+func FindFnKeyDevices() ([]*Device, error) {
+	entries, err := os.ReadDir(sysClassHidraw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", sysClassHidraw, err)
+	}
+
+	var devices []*Device
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "hidraw") {
+			continue
+		}
+		dev, ok := readDevice(entry.Name())
+		if !ok || dev.Vendor != asusVendorID {
+			continue
+		}
+		desc, err := os.ReadFile(filepath.Join(sysClassHidraw, entry.Name(), "device", "report_descriptor"))
+		if err != nil || !bytes.Contains(desc, vendorUsagePage) {
+			continue
+		}
+		devices = append(devices, dev)
+	}
+
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("no ASUS vendor HID interface found")
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		return nodeNumber(devices[i].Path) < nodeNumber(devices[j].Path)
+	})
+	return devices, nil
+}
+
+// readDevice parses the HID_ID and HID_NAME of a hidraw node's parent.
+func readDevice(node string) (*Device, bool) {
+	f, err := os.Open(filepath.Join(sysClassHidraw, node, "device", "uevent"))
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	dev := &Device{Path: filepath.Join(hidrawDevDir, node)}
+	found := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), "=")
+		switch key {
+		case "HID_NAME":
+			dev.Name = value
+		case "HID_ID":
+			// bus:vendor:product, e.g. 0003:00000B05:00001B2C
+			parts := strings.Split(value, ":")
+			if len(parts) != 3 {
+				return nil, false
+			}
+			bus, err1 := strconv.ParseUint(parts[0], 16, 16)
+			vendor, err2 := strconv.ParseUint(parts[1], 16, 32)
+			product, err3 := strconv.ParseUint(parts[2], 16, 32)
+			if err1 != nil || err2 != nil || err3 != nil {
+				return nil, false
+			}
+			dev.Bus, dev.Vendor, dev.Product = uint16(bus), uint32(vendor), uint32(product)
+			found = true
+		}
+	}
+	return dev, found
+}
+
+// nodeNumber returns N for /dev/hidrawN.
+func nodeNumber(path string) int {
+	n, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(path), "hidraw"))
+	if err != nil {
+		return -1
+	}
+	return n
+}
@@ -0,0 +1,144 @@
+package hidraw
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+)
+
+// report pads a captured report prefix to the keyboard's 32-byte report size.
+func report(prefix ...byte) []byte {
+	r := make([]byte, 32)
+	copy(r, prefix)
+	return r
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name   string
+		report []byte
+		want   events.SystemEvent
+		ok     bool
+	}{
+		{"mic mute", report(0x5a, 0x7c), events.MicMuteLedToggle, true},
+		{"backlight cycle", report(0x5a, 0xc7), events.BacklightToggle, true},
+		{"screen swap", report(0x5a, 0x6a), events.SecondaryDisplayToggle, true},
+		{"touchpad toggle", report(0x5a, 0x6b), events.TouchpadToggle, true},
+		{"short bluetooth report", []byte{0x5a, 0x7c, 0x00, 0x00, 0x00, 0x00}, events.MicMuteLedToggle, true},
+		{"key release", report(0x5a, 0x00), events.SystemEventNone, false},
+		{"unknown code", report(0x5a, 0x10), events.SystemEventNone, false},
+		{"other report id", report(0x5d, 0x7c), events.SystemEventNone, false},
+		{"init handshake reply", report(0x5a, 'A', 'S', 'U', 'S', ' ', 'T', 'e', 'c', 'h', '.', 'I', 'n', 'c', '.'), events.SystemEventNone, false},
+		{"empty", nil, events.SystemEventNone, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Decode(tt.report)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// fakeHidraw describes a hidraw node for fakeHidrawTree.
+type fakeHidraw struct {
+	hidID      string
+	name       string
+	descriptor []byte
+}
+
+// fakeHidrawTree creates /dev and /sys/class/hidraw look-alikes and points
+// the package at them for the duration of the test.
+func fakeHidrawTree(t *testing.T, nodes map[string]fakeHidraw) {
+	t.Helper()
+	devDir := t.TempDir()
+	sysDir := t.TempDir()
+	for node, d := range nodes {
+		require.NoError(t, os.WriteFile(filepath.Join(devDir, node), nil, 0600))
+		dev := filepath.Join(sysDir, node, "device")
+		require.NoError(t, os.MkdirAll(dev, 0755))
+		uevent := "DRIVER=asus\nHID_ID=" + d.hidID + "\nHID_NAME=" + d.name + "\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dev, "uevent"), []byte(uevent), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dev, "report_descriptor"), d.descriptor, 0644))
+	}
+
+	oldDev, oldSys := hidrawDevDir, sysClassHidraw
+	hidrawDevDir, sysClassHidraw = devDir, sysDir
+	t.Cleanup(func() { hidrawDevDir, sysClassHidraw = oldDev, oldSys })
+}
+
+func TestFindFnKeyDevices(t *testing.T) {
+	keyboard := []byte{0x05, 0x01, 0x09, 0x06, 0xa1, 0x01, 0x85, 0x01}
+	vendor := []byte{0x06, 0x31, 0xff, 0x09, 0x76, 0xa1, 0x01, 0x85, 0x5a}
+	fakeHidrawTree(t, map[string]fakeHidraw{
+		"hidraw0":  {hidID: "0003:00000B05:00001B2C", name: "ASUSTeK Zenbook Duo Keyboard", descriptor: keyboard},
+		"hidraw1":  {hidID: "0003:00000B05:00001B2C", name: "ASUSTeK Zenbook Duo Keyboard", descriptor: vendor},
+		"hidraw10": {hidID: "0005:00000B05:00001B2D", name: "ASUS Zenbook Duo Keyboard", descriptor: vendor},
+		"hidraw3":  {hidID: "0003:0000046D:0000C52B", name: "Logitech Receiver", descriptor: vendor},
+	})
+
+	devices, err := FindFnKeyDevices()
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	assert.Equal(t, "hidraw1", filepath.Base(devices[0].Path))
+	assert.Equal(t, uint16(0x03), devices[0].Bus)
+	assert.Equal(t, uint32(0x1b2c), devices[0].Product)
+	assert.Equal(t, "hidraw10", filepath.Base(devices[1].Path))
+	assert.Equal(t, uint16(0x05), devices[1].Bus)
+}
+
+func TestFindFnKeyDevices_None(t *testing.T) {
+	fakeHidrawTree(t, map[string]fakeHidraw{
+		"hidraw0": {hidID: "0003:0000046D:0000C52B", name: "Logitech Receiver"},
+	})
+
+	_, err := FindFnKeyDevices()
+	assert.ErrorContains(t, err, "no ASUS vendor HID interface found")
+}
+
+func TestMonitor_PublishesHotkeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hidraw1")
+	require.NoError(t, syscall.Mkfifo(path, 0600))
+
+	bus := events.NewSystemEventBus(zerolog.Nop())
+	defer bus.Close()
+	sub := bus.Subscribe()
+
+	m := NewMonitor(&Device{Path: path, Name: "fake"}, bus, zerolog.Nop())
+	writer := make(chan *os.File)
+	go func() {
+		// Opening a FIFO for writing blocks until the monitor opens it
+		f, err := os.OpenFile(path, os.O_WRONLY, 0)
+		require.NoError(t, err)
+		writer <- f
+	}()
+	require.NoError(t, m.Start(context.Background()))
+	w := <-writer
+	defer w.Close()
+
+	for _, r := range [][]byte{report(0x5a, 0x7c), report(0x5a, 0x00), report(0x5a, 0x6b)} {
+		_, err := w.Write(r)
+		require.NoError(t, err)
+		// One report per read, as hidraw delivers them
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for _, want := range []events.SystemEvent{events.MicMuteLedToggle, events.TouchpadToggle} {
+		select {
+		case got := <-sub:
+			assert.Equal(t, want, got)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+	require.NoError(t, m.Stop())
+}
@@ -0,0 +1,90 @@
+package hidraw
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/privsep"
+)
+
+// maxReportSize is larger than any report the keyboard sends.
+const maxReportSize = 64
+
+// Monitor reads hotkey reports from a hidraw node and publishes the
+// decoded events on the system event bus.
+type Monitor struct {
+	ctx            context.Context
+	cancel         context.CancelFunc
+	device         *Device
+	file           *os.File
+	systemEventBus *events.SystemEventBus
+	logger         zerolog.Logger
+	done           chan struct{}
+}
+
+// NewMonitor creates a hotkey monitor for device.
+func NewMonitor(device *Device, systemEventBus *events.SystemEventBus, logger zerolog.Logger) *Monitor {
+	return &Monitor{
+		device:         device,
+		systemEventBus: systemEventBus,
+		logger:         logger.With().Str("component", "hotkeys").Str("device", device.Path).Logger(),
+	}
+}
+
+// Start opens the hidraw node and starts reading reports.
+func (m *Monitor) Start(ctx context.Context) error {
+	m.ctx, m.cancel = context.WithCancel(ctx)
+
+	f, err := privsep.OpenFile(m.device.Path, os.O_RDONLY, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open hidraw device %s: %w", m.device.Path, err)
+	}
+	m.file = f
+	m.done = make(chan struct{})
+
+	m.logger.Info().Str("name", m.device.Name).Msg("Hotkey monitor started")
+	go m.readLoop()
+	return nil
+}
+
+// Stop stops the monitor and closes the device.
+func (m *Monitor) Stop() error {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	if m.file != nil {
+		m.file.Close()
+		<-m.done
+		m.file = nil
+	}
+	m.logger.Info().Msg("Hotkey monitor stopped")
+	return nil
+}
+
+// readLoop reads one report per read until the device is closed.
+func (m *Monitor) readLoop() {
+	defer close(m.done)
+	buf := make([]byte, maxReportSize)
+	for {
+		n, err := m.file.Read(buf)
+		if err != nil {
+			if m.ctx.Err() != nil {
+				return // Context cancelled
+			}
+			m.logger.Error().Err(err).Msg("Hotkey read error")
+			return
+		}
+
+		event, ok := Decode(buf[:n])
+		if !ok {
+			m.logger.Debug().Hex("report", buf[:n]).Msg("Ignoring report")
+			continue
+		}
+		m.logger.Debug().Str("event", event.String()).Msg("Hotkey pressed")
+		m.systemEventBus.Publish(event)
+	}
+}
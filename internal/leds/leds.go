@@ -0,0 +1,91 @@
+// Package leds drives LEDs exposed by the kernel LED class in sysfs, such as
+// the mic-mute indicator and the keyboard backlight.
+package leds
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/privsep"
+)
+
+// sysClassLeds is the LED class directory.
+const sysClassLeds = "/sys/class/leds"
+
+// Function suffixes of the LEDs the daemon drives.
+const (
+	MicMute      = "micmute"
+	KbdBacklight = "kbd_backlight"
+)
+
+// LED is one LED class device.
+type LED struct {
+	Name string
+	dir  string
+}
+
+// Find returns the LEDs whose name ends in "::<function>", sorted by name.
+func Find(function string) ([]*LED, error) {
+	return FindIn(sysClassLeds, function)
+}
+
+// FindIn is Find on an LED class directory other than /sys/class/leds.
+func FindIn(root, function string) ([]*LED, error) {
+	matches, err := filepath.Glob(filepath.Join(root, "*::"+function))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+	leds := make([]*LED, 0, len(matches))
+	for _, dir := range matches {
+		leds = append(leds, &LED{Name: filepath.Base(dir), dir: dir})
+	}
+	if len(leds) == 0 {
+		return nil, fmt.Errorf("no *::%s LED found in %s", function, root)
+	}
+	return leds, nil
+}
+
+// Brightness returns the current brightness.
+func (l *LED) Brightness() (int, error) {
+	return l.readInt("brightness")
+}
+
+// MaxBrightness returns the highest brightness the LED supports.
+func (l *LED) MaxBrightness() (int, error) {
+	return l.readInt("max_brightness")
+}
+
+// SetBrightness sets the brightness, clamped to [0, max_brightness].
+func (l *LED) SetBrightness(value int) error {
+	limit, err := l.MaxBrightness()
+	if err != nil {
+		return err
+	}
+	value = min(max(value, 0), limit)
+	f, err := privsep.OpenFile(filepath.Join(l.dir, "brightness"), os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("failed to set %s brightness: %w", l.Name, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(strconv.Itoa(value)); err != nil {
+		return fmt.Errorf("failed to set %s brightness: %w", l.Name, err)
+	}
+	return nil
+}
+
+func (l *LED) readInt(attr string) (int, error) {
+	data, err := os.ReadFile(filepath.Join(l.dir, attr))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s %s: %w", l.Name, attr, err)
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %s: %w", l.Name, attr, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,62 @@
+package leds
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLED creates an LED class directory under root.
+func fakeLED(t *testing.T, root, name string, brightness, max int) {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "brightness"), []byte(strconv.Itoa(brightness)+"\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "max_brightness"), []byte(strconv.Itoa(max)+"\n"), 0644))
+}
+
+func TestFindIn(t *testing.T) {
+	root := t.TempDir()
+	fakeLED(t, root, "platform::micmute", 0, 1)
+	fakeLED(t, root, "hda::micmute", 0, 1)
+	fakeLED(t, root, "asus::kbd_backlight", 1, 3)
+	fakeLED(t, root, "input3::capslock", 0, 1)
+
+	mic, err := FindIn(root, MicMute)
+	require.NoError(t, err)
+	require.Len(t, mic, 2)
+	assert.Equal(t, "hda::micmute", mic[0].Name)
+	assert.Equal(t, "platform::micmute", mic[1].Name)
+
+	kbd, err := FindIn(root, KbdBacklight)
+	require.NoError(t, err)
+	require.Len(t, kbd, 1)
+
+	_, err = FindIn(root, "scrolllock")
+	assert.ErrorContains(t, err, "no *::scrolllock LED found")
+}
+
+func TestSetBrightness(t *testing.T) {
+	root := t.TempDir()
+	fakeLED(t, root, "asus::kbd_backlight", 0, 3)
+	found, err := FindIn(root, KbdBacklight)
+	require.NoError(t, err)
+	led := found[0]
+
+	require.NoError(t, led.SetBrightness(2))
+	b, err := led.Brightness()
+	require.NoError(t, err)
+	assert.Equal(t, 2, b)
+
+	require.NoError(t, led.SetBrightness(10))
+	b, _ = led.Brightness()
+	assert.Equal(t, 3, b, "clamped to max_brightness")
+
+	require.NoError(t, led.SetBrightness(-1))
+	b, _ = led.Brightness()
+	assert.Equal(t, 0, b)
+}
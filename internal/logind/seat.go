@@ -0,0 +1,203 @@
+package logind
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/rs/zerolog"
+)
+
+// DefaultSeat is the seat with the built-in keyboard and touchpads.
+const DefaultSeat = "seat0"
+
+const seatInterface = "org.freedesktop.login1.Seat"
+
+// SeatState describes who is in front of the machine.
+type SeatState struct {
+	// Session is the seat's active session; empty if there is none.
+	Session string `json:"session,omitempty"`
+	// User is the name of the session's user.
+	User string `json:"user,omitempty"`
+	UID  int    `json:"uid,omitempty"`
+	// Class is the session class: "user", "greeter", "lock-screen", ...
+	Class string `json:"class,omitempty"`
+	// Locked is set while the session's screen is locked.
+	Locked bool `json:"locked"`
+}
+
+// Present reports whether a user is actively using the seat: there is an
+// unlocked user session in the foreground, not a greeter or lock screen.
+func (s SeatState) Present() bool {
+	return s.Session != "" && s.Class == "user" && !s.Locked
+}
+
+// objectGetter is implemented by *dbus.Conn; replaced in tests.
+type objectGetter interface {
+	Object(dest string, path dbus.ObjectPath) dbus.BusObject
+}
+
+// Seat follows the active session of a seat for a daemon running as root.
+type Seat struct {
+	conn     *dbus.Conn
+	bus      objectGetter
+	name     string
+	path     dbus.ObjectPath
+	logger   zerolog.Logger
+	onChange func(SeatState)
+	signals  chan *dbus.Signal
+	done     chan struct{}
+
+	mu    sync.Mutex
+	state SeatState
+}
+
+// OpenSeat connects to logind and reads the current state of the named seat.
+func OpenSeat(name string, logger zerolog.Logger) (*Seat, error) {
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the system bus: %w", err)
+	}
+	s := newSeat(conn, name, logger)
+	s.conn = conn
+	if err := conn.Object(busName, managerPath).Call(managerInterface+".GetSeat", 0, name).Store(&s.path); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to find seat %s: %w", name, err)
+	}
+	if err := s.refresh(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func newSeat(bus objectGetter, name string, logger zerolog.Logger) *Seat {
+	return &Seat{
+		bus:    bus,
+		name:   name,
+		logger: logger.With().Str("component", "seat").Str("seat", name).Logger(),
+		done:   make(chan struct{}),
+	}
+}
+
+// SetOnChange sets the callback run whenever the state changes. Call
+// before Start.
+func (s *Seat) SetOnChange(fn func(SeatState)) {
+	s.onChange = fn
+}
+
+// State returns the current state.
+func (s *Seat) State() SeatState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// Start follows session switches and screen locks on the seat.
+func (s *Seat) Start(ctx context.Context) error {
+	for _, opt := range [][]dbus.MatchOption{
+		{dbus.WithMatchObjectPath(s.path), dbus.WithMatchInterface(propsInterface), dbus.WithMatchMember("PropertiesChanged")},
+		{dbus.WithMatchPathNamespace("/org/freedesktop/login1/session"), dbus.WithMatchInterface(propsInterface), dbus.WithMatchMember("PropertiesChanged")},
+		{dbus.WithMatchPathNamespace("/org/freedesktop/login1/session"), dbus.WithMatchInterface(sessionInterface)},
+	} {
+		if err := s.conn.AddMatchSignal(opt...); err != nil {
+			return fmt.Errorf("failed to subscribe to seat signals: %w", err)
+		}
+	}
+	s.signals = make(chan *dbus.Signal, 16)
+	s.conn.Signal(s.signals)
+
+	go s.signalLoop(ctx)
+	state := s.State()
+	s.logger.Info().Str("session", state.Session).Str("user", state.User).Bool("locked", state.Locked).Msg("Following seat")
+	return nil
+}
+
+// Stop stops following the seat.
+func (s *Seat) Stop() error {
+	if s.signals != nil {
+		s.conn.RemoveSignal(s.signals)
+		close(s.done)
+	}
+	if s.conn != nil {
+		return s.conn.Close()
+	}
+	return nil
+}
+
+func (s *Seat) signalLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.done:
+			return
+		case _, ok := <-s.signals:
+			if !ok {
+				return
+			}
+			// Any seat or session change may matter; re-read the state
+			if err := s.refresh(); err != nil {
+				s.logger.Warn().Err(err).Msg("failed to read seat state")
+			}
+		}
+	}
+}
+
+// refresh reads the seat state and runs the callback if it changed.
+func (s *Seat) refresh() error {
+	state, err := s.read()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	changed := state != s.state
+	s.state = state
+	s.mu.Unlock()
+	if !changed {
+		return nil
+	}
+	s.logger.Info().
+		Str("session", state.Session).
+		Str("user", state.User).
+		Str("class", state.Class).
+		Bool("locked", state.Locked).
+		Msg("Seat state changed")
+	if s.onChange != nil {
+		s.onChange(state)
+	}
+	return nil
+}
+
+func (s *Seat) read() (SeatState, error) {
+	var active struct {
+		ID   string
+		Path dbus.ObjectPath
+	}
+	if err := s.bus.Object(busName, s.path).StoreProperty(seatInterface+".ActiveSession", &active); err != nil {
+		return SeatState{}, err
+	}
+	if active.ID == "" {
+		return SeatState{}, nil
+	}
+
+	session := s.bus.Object(busName, active.Path)
+	state := SeatState{Session: active.ID}
+	var user struct {
+		UID  uint32
+		Path dbus.ObjectPath
+	}
+	for prop, v := range map[string]any{
+		"Name":       &state.User,
+		"User":       &user,
+		"Class":      &state.Class,
+		"LockedHint": &state.Locked,
+	} {
+		if err := session.StoreProperty(sessionInterface+"."+prop, v); err != nil {
+			return SeatState{}, fmt.Errorf("session %s: %w", active.ID, err)
+		}
+	}
+	state.UID = int(user.UID)
+	return state, nil
+}
@@ -0,0 +1,77 @@
+package logind
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBus serves properties per object path.
+type fakeBus map[dbus.ObjectPath]map[string]any
+
+func (b fakeBus) Object(_ string, path dbus.ObjectPath) dbus.BusObject {
+	return fakeObject{props: b[path]}
+}
+
+type fakeObject struct {
+	dbus.BusObject
+	props map[string]any
+}
+
+func (o fakeObject) StoreProperty(p string, value any) error {
+	v, ok := o.props[p]
+	if !ok {
+		return dbus.ErrMsgNoObject
+	}
+	return dbus.Store([]any{v}, value)
+}
+
+func TestSeat_Refresh(t *testing.T) {
+	const seatPath = dbus.ObjectPath("/org/freedesktop/login1/seat/seat0")
+	bus := fakeBus{
+		seatPath: {seatInterface + ".ActiveSession": []any{"2", dbus.ObjectPath("/s/2")}},
+		"/s/2": {
+			sessionInterface + ".Name":       "alice",
+			sessionInterface + ".User":       []any{uint32(1000), dbus.ObjectPath("/u/1000")},
+			sessionInterface + ".Class":      "user",
+			sessionInterface + ".LockedHint": false,
+		},
+	}
+	s := newSeat(bus, DefaultSeat, zerolog.Nop())
+	s.path = seatPath
+	var changes []SeatState
+	s.SetOnChange(func(state SeatState) { changes = append(changes, state) })
+
+	require.NoError(t, s.refresh())
+	alice := SeatState{Session: "2", User: "alice", UID: 1000, Class: "user"}
+	assert.Equal(t, []SeatState{alice}, changes)
+	assert.True(t, s.State().Present())
+
+	require.NoError(t, s.refresh())
+	assert.Len(t, changes, 1, "unchanged state is not reported again")
+
+	bus["/s/2"][sessionInterface+".LockedHint"] = true
+	require.NoError(t, s.refresh())
+	assert.True(t, changes[1].Locked)
+	assert.False(t, s.State().Present())
+
+	// Switched to the greeter
+	bus[seatPath][seatInterface+".ActiveSession"] = []any{"c1", dbus.ObjectPath("/s/c1")}
+	bus["/s/c1"] = map[string]any{
+		sessionInterface + ".Name":       "gdm",
+		sessionInterface + ".User":       []any{uint32(120), dbus.ObjectPath("/u/120")},
+		sessionInterface + ".Class":      "greeter",
+		sessionInterface + ".LockedHint": false,
+	}
+	require.NoError(t, s.refresh())
+	assert.Equal(t, "gdm", s.State().User)
+	assert.False(t, s.State().Present())
+
+	bus[seatPath][seatInterface+".ActiveSession"] = []any{"", dbus.ObjectPath("/")}
+	require.NoError(t, s.refresh())
+	assert.Equal(t, SeatState{}, s.State())
+	assert.Len(t, changes, 4)
+}
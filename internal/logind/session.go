@@ -0,0 +1,336 @@
+// Package logind talks to systemd-logind. Session lets the daemon run
+// without root inside a user's login session: it takes control of the
+// session, opens input devices with TakeDevice instead of relying on file
+// permissions, and reports when the session becomes inactive (VT switch,
+// user switch), at which point logind revokes the devices and the daemon
+// must let go of them. logind hands devices only to the one controller of
+// a session, so this works in sessions no compositor or X server controls.
+// Seat lets the root daemon follow which user is in front of the machine
+// and whether their screen is locked.
+package logind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/rs/zerolog"
+)
+
+const (
+	busName          = "org.freedesktop.login1"
+	managerPath      = dbus.ObjectPath("/org/freedesktop/login1")
+	managerInterface = "org.freedesktop.login1.Manager"
+	sessionInterface = "org.freedesktop.login1.Session"
+	propsInterface   = "org.freedesktop.DBus.Properties"
+	// errBusy is the error TakeControl returns when the session already
+	// has a controller (sd-bus names unmapped errnos this way)
+	errBusy = "System.Error.EBUSY"
+)
+
+// Session is the login session the daemon runs in.
+type Session struct {
+	conn     *dbus.Conn
+	obj      dbus.BusObject
+	id       string
+	logger   zerolog.Logger
+	onChange func(active bool)
+	signals  chan *dbus.Signal
+	done     chan struct{}
+
+	mu      sync.Mutex
+	active  bool
+	devices map[uint64]string // taken devices by device number
+}
+
+// ErrControlled is returned by Open when another process, normally the
+// compositor or X server, already controls the session. logind gives
+// devices only to that controller and has no way to share them.
+var ErrControlled = errors.New("session is already controlled by another process (usually the compositor or X server)")
+
+// Open connects to logind and takes control of the caller's session: the
+// one in XDG_SESSION_ID, or otherwise the user's graphical session. Only
+// one process may control a session, so this fails with ErrControlled if
+// the compositor already does.
+func Open(logger zerolog.Logger) (*Session, error) {
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the system bus: %w", err)
+	}
+	s, err := open(conn, logger)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func open(conn *dbus.Conn, logger zerolog.Logger) (*Session, error) {
+	id := os.Getenv("XDG_SESSION_ID")
+	if id == "" {
+		// "auto" is the caller's session or else the user's graphical
+		// session. It is resolved on every call, so signals need the real path
+		v, err := conn.Object(busName, "/org/freedesktop/login1/session/auto").GetProperty(sessionInterface + ".Id")
+		if err != nil {
+			return nil, fmt.Errorf("no login session for this process or user: %w", err)
+		}
+		id, _ = v.Value().(string)
+	}
+	var path dbus.ObjectPath
+	if err := conn.Object(busName, managerPath).Call(managerInterface+".GetSession", 0, id).Store(&path); err != nil {
+		return nil, fmt.Errorf("failed to find session %s: %w", id, err)
+	}
+	s := newSession(conn.Object(busName, path), logger.With().Str("session", id).Logger())
+	s.conn = conn
+	s.id = id
+
+	active, err := s.obj.GetProperty(sessionInterface + ".Active")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session state: %w", err)
+	}
+	s.active, _ = active.Value().(bool)
+
+	if err := s.takeControl(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// takeControl makes the daemon the session controller, without forcing
+// out a controller that is already there.
+func (s *Session) takeControl() error {
+	err := s.obj.Call(sessionInterface+".TakeControl", 0, false).Err
+	var dbusErr dbus.Error
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &dbusErr) && dbusErr.Name == errBusy:
+		return fmt.Errorf("cannot take control of session %s: %w", s.id, ErrControlled)
+	default:
+		return fmt.Errorf("failed to take control of session %s: %w", s.id, err)
+	}
+}
+
+func newSession(obj dbus.BusObject, logger zerolog.Logger) *Session {
+	return &Session{
+		obj:     obj,
+		logger:  logger.With().Str("component", "logind").Logger(),
+		devices: make(map[uint64]string),
+		done:    make(chan struct{}),
+	}
+}
+
+// SetOnChange sets the callback run when the session becomes active or
+// inactive. Devices are revoked by logind while the session is inactive;
+// the callback should close them and call ReleaseDevices. Call before Start.
+func (s *Session) SetOnChange(fn func(active bool)) {
+	s.onChange = fn
+}
+
+// ID returns the logind session ID.
+func (s *Session) ID() string {
+	return s.id
+}
+
+// Active reports whether the session is in the foreground.
+func (s *Session) Active() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.active
+}
+
+// Start follows the session's state.
+func (s *Session) Start(ctx context.Context) error {
+	for _, opt := range [][]dbus.MatchOption{
+		{dbus.WithMatchObjectPath(s.obj.Path()), dbus.WithMatchInterface(sessionInterface)},
+		{dbus.WithMatchObjectPath(s.obj.Path()), dbus.WithMatchInterface(propsInterface), dbus.WithMatchMember("PropertiesChanged")},
+	} {
+		if err := s.conn.AddMatchSignal(opt...); err != nil {
+			return fmt.Errorf("failed to subscribe to session signals: %w", err)
+		}
+	}
+	s.signals = make(chan *dbus.Signal, 16)
+	s.conn.Signal(s.signals)
+
+	go s.signalLoop(ctx)
+	s.logger.Info().Bool("active", s.Active()).Msg("Following logind session")
+	return nil
+}
+
+// Stop releases every device and control of the session.
+func (s *Session) Stop() error {
+	if s.signals != nil {
+		s.conn.RemoveSignal(s.signals)
+		close(s.done)
+	}
+	// Releasing control releases all devices as well
+	err := s.obj.Call(sessionInterface+".ReleaseControl", 0).Err
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	s.logger.Info().Msg("Released logind session")
+	return err
+}
+
+// Open takes the device node at path from logind. It implements
+// privsep.Opener; flag is ignored since logind opens devices read-write.
+func (s *Session) Open(path string, _ int) (*os.File, error) {
+	devnum, err := deviceNumber(path)
+	if err != nil {
+		return nil, err
+	}
+	if !s.Active() {
+		return nil, fmt.Errorf("cannot open %s: session %s is not active", path, s.id)
+	}
+
+	var fd dbus.UnixFD
+	var inactive bool
+	if err := s.obj.Call(sessionInterface+".TakeDevice", 0, major(devnum), minor(devnum)).Store(&fd, &inactive); err != nil {
+		return nil, fmt.Errorf("logind TakeDevice %s: %w", path, err)
+	}
+	f := os.NewFile(uintptr(fd), path)
+	if inactive {
+		f.Close()
+		s.release(devnum)
+		return nil, fmt.Errorf("cannot open %s: session %s is not active", path, s.id)
+	}
+
+	s.mu.Lock()
+	s.devices[devnum] = path
+	s.mu.Unlock()
+	return f, nil
+}
+
+// ReleaseDevices hands every taken device back to logind. Descriptors
+// opened through the session must be closed first.
+func (s *Session) ReleaseDevices() {
+	s.mu.Lock()
+	devnums := make([]uint64, 0, len(s.devices))
+	for devnum := range s.devices {
+		devnums = append(devnums, devnum)
+	}
+	s.mu.Unlock()
+	for _, devnum := range devnums {
+		s.release(devnum)
+	}
+}
+
+func (s *Session) release(devnum uint64) {
+	s.mu.Lock()
+	path := s.devices[devnum]
+	delete(s.devices, devnum)
+	s.mu.Unlock()
+	if err := s.obj.Call(sessionInterface+".ReleaseDevice", 0, major(devnum), minor(devnum)).Err; err != nil {
+		s.logger.Debug().Err(err).Str("device", path).Msg("failed to release device")
+	}
+}
+
+func (s *Session) signalLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.done:
+			return
+		case sig, ok := <-s.signals:
+			if !ok {
+				return
+			}
+			s.handleSignal(sig)
+		}
+	}
+}
+
+func (s *Session) handleSignal(sig *dbus.Signal) {
+	if sig.Path != s.obj.Path() {
+		return
+	}
+	switch sig.Name {
+	case sessionInterface + ".PauseDevice":
+		var major, minor uint32
+		var kind string
+		if dbus.Store(sig.Body, &major, &minor, &kind) != nil {
+			return
+		}
+		if kind == "gone" {
+			s.mu.Lock()
+			delete(s.devices, mkdev(major, minor))
+			s.mu.Unlock()
+			return
+		}
+		// Let go of the devices before they are revoked
+		s.setActive(false)
+		if kind == "pause" {
+			// logind waits for this (or a timeout) before revoking
+			s.obj.Call(sessionInterface+".PauseDeviceComplete", 0, major, minor)
+		}
+
+	case sessionInterface + ".ResumeDevice":
+		// Devices are reopened from scratch once the session is active;
+		// the descriptor passed along is not needed
+		var major, minor uint32
+		var fd dbus.UnixFD
+		if dbus.Store(sig.Body, &major, &minor, &fd) == nil {
+			syscall.Close(int(fd))
+		}
+
+	case propsInterface + ".PropertiesChanged":
+		var iface string
+		var changed map[string]dbus.Variant
+		var invalidated []string
+		if dbus.Store(sig.Body, &iface, &changed, &invalidated) != nil || iface != sessionInterface {
+			return
+		}
+		if v, ok := changed["Active"]; ok {
+			if active, ok := v.Value().(bool); ok {
+				s.setActive(active)
+			}
+		}
+	}
+}
+
+// setActive runs the callback when the state changes.
+func (s *Session) setActive(active bool) {
+	s.mu.Lock()
+	changed := s.active != active
+	s.active = active
+	s.mu.Unlock()
+	if !changed {
+		return
+	}
+	s.logger.Info().Bool("active", active).Msg("Session state changed")
+	if s.onChange != nil {
+		s.onChange(active)
+	}
+}
+
+// deviceNumber returns the device number of the character device at path.
+func deviceNumber(path string) (uint64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || info.Mode()&os.ModeCharDevice == 0 {
+		return 0, errors.New(path + " is not a device node")
+	}
+	return st.Rdev, nil
+}
+
+// major, minor and mkdev follow the glibc encoding of dev_t.
+func major(dev uint64) uint32 {
+	return uint32((dev>>8)&0xfff | (dev>>32)&^0xfff)
+}
+
+func minor(dev uint64) uint32 {
+	return uint32(dev&0xff | (dev>>12)&^0xff)
+}
+
+func mkdev(major, minor uint32) uint64 {
+	return uint64(major&0xfff)<<8 | uint64(major&^0xfff)<<32 |
+		uint64(minor&0xff) | uint64(minor&^0xff)<<12
+}
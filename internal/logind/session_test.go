@@ -0,0 +1,134 @@
+package logind
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPath = dbus.ObjectPath("/org/freedesktop/login1/session/_32")
+
+// fakeSession records method calls; TakeDevice hands out a descriptor for
+// /dev/null.
+type fakeSession struct {
+	dbus.BusObject
+	calls    []string
+	inactive bool
+	errs     map[string]error
+}
+
+func (f *fakeSession) Path() dbus.ObjectPath { return testPath }
+
+func (f *fakeSession) Call(method string, _ dbus.Flags, args ...any) *dbus.Call {
+	f.calls = append(f.calls, method)
+	if err := f.errs[method]; err != nil {
+		return &dbus.Call{Err: err}
+	}
+	if method == sessionInterface+".TakeDevice" {
+		file, err := os.Open(os.DevNull)
+		if err != nil {
+			return &dbus.Call{Err: err}
+		}
+		fd, err := syscall.Dup(int(file.Fd()))
+		file.Close()
+		if err != nil {
+			return &dbus.Call{Err: err}
+		}
+		return &dbus.Call{Body: []any{dbus.UnixFD(fd), f.inactive}}
+	}
+	return &dbus.Call{}
+}
+
+func newTestSession(t *testing.T) (*Session, *fakeSession, *[]bool) {
+	t.Helper()
+	obj := &fakeSession{}
+	s := newSession(obj, zerolog.Nop())
+	s.active = true
+	var changes []bool
+	s.SetOnChange(func(active bool) { changes = append(changes, active) })
+	return s, obj, &changes
+}
+
+func TestSession_Open(t *testing.T) {
+	s, obj, _ := newTestSession(t)
+
+	f, err := s.Open(os.DevNull, os.O_RDWR)
+	require.NoError(t, err)
+	f.Close()
+	assert.Equal(t, []string{sessionInterface + ".TakeDevice"}, obj.calls)
+	assert.Len(t, s.devices, 1)
+
+	s.ReleaseDevices()
+	assert.Equal(t, sessionInterface+".ReleaseDevice", obj.calls[1])
+	assert.Empty(t, s.devices)
+
+	_, err = s.Open(t.TempDir(), os.O_RDWR)
+	assert.ErrorContains(t, err, "is not a device node")
+
+	obj.inactive = true
+	_, err = s.Open(os.DevNull, os.O_RDWR)
+	assert.ErrorContains(t, err, "is not active")
+	assert.Empty(t, s.devices)
+}
+
+func TestSession_TakeControl(t *testing.T) {
+	s, obj, _ := newTestSession(t)
+	require.NoError(t, s.takeControl())
+	assert.Equal(t, []string{sessionInterface + ".TakeControl"}, obj.calls)
+
+	obj.errs = map[string]error{sessionInterface + ".TakeControl": dbus.Error{Name: errBusy}}
+	assert.ErrorIs(t, s.takeControl(), ErrControlled, "a compositor controls the session")
+
+	obj.errs[sessionInterface+".TakeControl"] = dbus.Error{Name: "org.freedesktop.DBus.Error.AccessDenied"}
+	err := s.takeControl()
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrControlled)
+}
+
+func TestSession_Signals(t *testing.T) {
+	s, obj, changes := newTestSession(t)
+
+	s.handleSignal(&dbus.Signal{Path: testPath, Name: sessionInterface + ".PauseDevice", Body: []any{uint32(13), uint32(64), "pause"}})
+	assert.Equal(t, []string{sessionInterface + ".PauseDeviceComplete"}, obj.calls)
+	assert.Equal(t, []bool{false}, *changes)
+	assert.False(t, s.Active())
+
+	_, err := s.Open(os.DevNull, os.O_RDWR)
+	assert.ErrorContains(t, err, "is not active")
+
+	// A second paused device does not repeat the callback
+	s.handleSignal(&dbus.Signal{Path: testPath, Name: sessionInterface + ".PauseDevice", Body: []any{uint32(13), uint32(65), "force"}})
+	assert.Equal(t, []bool{false}, *changes)
+
+	props := func(active bool) *dbus.Signal {
+		return &dbus.Signal{Path: testPath, Name: propsInterface + ".PropertiesChanged", Body: []any{
+			sessionInterface, map[string]dbus.Variant{"Active": dbus.MakeVariant(active)}, []string{},
+		}}
+	}
+	s.handleSignal(props(true))
+	assert.Equal(t, []bool{false, true}, *changes)
+	assert.True(t, s.Active())
+
+	other := props(false)
+	other.Path = "/org/freedesktop/login1/session/_33"
+	s.handleSignal(other)
+	assert.True(t, s.Active(), "signals of other sessions are ignored")
+}
+
+func TestDeviceNumbers(t *testing.T) {
+	for _, dev := range []struct{ major, minor uint32 }{{13, 64}, {1, 3}, {4095, 255}, {4096, 1 << 19}} {
+		n := mkdev(dev.major, dev.minor)
+		assert.Equal(t, dev.major, major(n))
+		assert.Equal(t, dev.minor, minor(n))
+	}
+
+	n, err := deviceNumber(os.DevNull)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), major(n))
+	assert.Equal(t, uint32(3), minor(n))
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"time"
+)
+
+const namespace = "palm_reject"
+
+var (
+	// durationBuckets covers suppression episodes: a single keystroke
+	// (cooldown only) up to long typing sessions.
+	durationBuckets = []float64{0.25, 0.5, 1, 2, 5, 10, 30, 60, 300}
+	// latencyBuckets covers EVIOCGRAB/ungrab ioctls.
+	latencyBuckets = []float64{0.00005, 0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.05}
+)
+
+// Metrics holds the palm-rejection statistics. All Observe methods are safe
+// to call on a nil *Metrics, so instrumented components work unchanged when
+// the exporter is disabled.
+type Metrics struct {
+	registry *Registry
+
+	keystrokes          *CounterVec
+	suppressions        *CounterVec
+	suppressionDuration *HistogramVec
+	deviceOpLatency     *HistogramVec
+	deviceOpErrors      *CounterVec
+	busDropped          *CounterVec
+	busDelayed          *CounterVec
+	commands            *CounterVec
+}
+
+// New creates the palm-rejection metric families in a fresh registry.
+func New() *Metrics {
+	r := NewRegistry()
+	return &Metrics{
+		registry: r,
+		keystrokes: r.NewCounterVec(namespace+"_keystrokes",
+			"Key presses observed by the keyboard monitor."),
+		suppressions: r.NewCounterVec(namespace+"_suppression_episodes",
+			"Times the touchpad was suppressed, by reason.", "reason"),
+		suppressionDuration: r.NewHistogramVec(namespace+"_suppression_duration_seconds",
+			"How long the touchpad stayed suppressed, by restore reason.", durationBuckets, "reason"),
+		deviceOpLatency: r.NewHistogramVec(namespace+"_touchpad_op_duration_seconds",
+			"Latency of touchpad grab/ungrab operations.", latencyBuckets, "device", "op"),
+		deviceOpErrors: r.NewCounterVec(namespace+"_touchpad_op_errors",
+			"Failed touchpad grab/ungrab operations.", "device", "op"),
+		busDropped: r.NewCounterVec(namespace+"_bus_events_dropped",
+			"System events dropped by the event bus, by subscriber.", "subscriber"),
+		busDelayed: r.NewCounterVec(namespace+"_bus_events_delayed",
+			"System events whose publication waited for a subscriber.", "subscriber"),
+		commands: r.NewCounterVec(namespace+"_commands_received",
+			"Commands received over the pipe and control socket.", "source", "command"),
+	}
+}
+
+// Registry returns the registry backing the metrics.
+func (m *Metrics) Registry() *Registry {
+	return m.registry
+}
+
+// ObserveKeystroke counts one key press. The key itself is deliberately not
+// a label: metrics must not reveal what was typed.
+func (m *Metrics) ObserveKeystroke() {
+	if m == nil {
+		return
+	}
+	m.keystrokes.Inc()
+}
+
+// ObserveSuppression counts the start of a suppression episode.
+func (m *Metrics) ObserveSuppression(reason string) {
+	if m == nil {
+		return
+	}
+	m.suppressions.Inc(reason)
+}
+
+// ObserveRestore records the length of a finished suppression episode.
+func (m *Metrics) ObserveRestore(reason string, d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.suppressionDuration.Observe(d.Seconds(), reason)
+}
+
+// ObserveDeviceOp records the latency and outcome of a grab/ungrab.
+func (m *Metrics) ObserveDeviceOp(device, op string, d time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.deviceOpLatency.Observe(d.Seconds(), device, op)
+	if err != nil {
+		m.deviceOpErrors.Inc(device, op)
+	}
+}
+
+// ObserveBusDrop counts an event dropped for a subscriber.
+func (m *Metrics) ObserveBusDrop(subscriber string) {
+	if m == nil {
+		return
+	}
+	m.busDropped.Inc(subscriber)
+}
+
+// ObserveBusDelay counts an event whose publication had to wait.
+func (m *Metrics) ObserveBusDelay(subscriber string) {
+	if m == nil {
+		return
+	}
+	m.busDelayed.Inc(subscriber)
+}
+
+// ObserveCommand counts a command from source ("pipe" or "control").
+// Unrecognised commands should be passed as "unknown" to bound cardinality.
+func (m *Metrics) ObserveCommand(source, command string) {
+	if m == nil {
+		return
+	}
+	m.commands.Inc(source, command)
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/endpoint"
+)
+
+func TestRegistry_WriteOpenMetrics(t *testing.T) {
+	r := NewRegistry()
+	plain := r.NewCounterVec("test_plain", "A counter without labels.")
+	labelled := r.NewCounterVec("test_labelled", "A counter with labels.", "op")
+	hist := r.NewHistogramVec("test_seconds", "A histogram.", []float64{0.1, 1}, "op")
+
+	plain.Inc()
+	labelled.Add(2, "grab")
+	hist.Observe(0.05, "grab")
+	hist.Observe(0.5, "grab")
+	hist.Observe(5, "grab")
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteOpenMetrics(&buf))
+	assert.Equal(t, `# TYPE test_plain counter
+# HELP test_plain A counter without labels.
+test_plain_total 1
+# TYPE test_labelled counter
+# HELP test_labelled A counter with labels.
+test_labelled_total{op="grab"} 2
+# TYPE test_seconds histogram
+# HELP test_seconds A histogram.
+test_seconds_bucket{op="grab",le="0.1"} 1
+test_seconds_bucket{op="grab",le="1"} 2
+test_seconds_bucket{op="grab",le="+Inf"} 3
+test_seconds_sum{op="grab"} 5.55
+test_seconds_count{op="grab"} 3
+# EOF
+`, buf.String())
+}
+
+func TestMetrics_Observe(t *testing.T) {
+	m := New()
+	m.ObserveKeystroke()
+	m.ObserveKeystroke()
+	m.ObserveSuppression("typing")
+	m.ObserveRestore("typing", 400*time.Millisecond)
+	m.ObserveDeviceOp("/dev/input/event5", "grab", 100*time.Microsecond, nil)
+	m.ObserveDeviceOp("/dev/input/event5", "grab", 100*time.Microsecond, errors.New("busy"))
+	m.ObserveCommand("pipe", "touchpad_toggle")
+
+	assert.Equal(t, float64(2), m.keystrokes.Value())
+	assert.Equal(t, float64(1), m.suppressions.Value("typing"))
+	assert.Equal(t, uint64(1), m.suppressionDuration.Count("typing"))
+	assert.Equal(t, uint64(2), m.deviceOpLatency.Count("/dev/input/event5", "grab"))
+	assert.Equal(t, float64(1), m.deviceOpErrors.Value("/dev/input/event5", "grab"))
+	assert.Equal(t, float64(1), m.commands.Value("pipe", "touchpad_toggle"))
+
+	rec := httptest.NewRecorder()
+	Handler(m).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "palm_reject_keystrokes_total 2\n")
+}
+
+func TestMetrics_NilSafe(t *testing.T) {
+	var m *Metrics
+	assert.NotPanics(t, func() {
+		m.ObserveKeystroke()
+		m.ObserveSuppression("typing")
+		m.ObserveRestore("typing", time.Second)
+		m.ObserveDeviceOp("dev", "grab", time.Millisecond, nil)
+		m.ObserveBusDrop("sub")
+		m.ObserveBusDelay("sub")
+		m.ObserveCommand("pipe", "x")
+	})
+}
+
+func TestServer_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.sock")
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+	s := NewServer("unix:"+path, New(), zerolog.Nop())
+	s.SetPermissions(endpoint.Permissions{UID: -1, GID: -1, Mode: 0640})
+	assert.ErrorContains(t, s.Start(context.Background()), "is not a socket", "only a leftover socket is replaced")
+
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, s.Start(context.Background()))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+
+	client := http.Client{Transport: &http.Transport{DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, "unix", path)
+	}}}
+	resp, err := client.Get("http://metrics/metrics")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, ContentType, resp.Header.Get("Content-Type"))
+
+	require.NoError(t, s.Stop())
+	assert.NoFileExists(t, path)
+}
@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/endpoint"
+)
+
+// ContentType is the OpenMetrics text exposition media type.
+const ContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// Server exposes metrics over HTTP at /metrics.
+type Server struct {
+	addr    string
+	metrics *Metrics
+	server  *http.Server
+	perm    endpoint.Permissions
+	// socket is the Unix socket created by Start, removed by Stop
+	socket string
+	logger zerolog.Logger
+}
+
+// NewServer creates a metrics server. addr is either a TCP address such as
+// "127.0.0.1:9477" or a Unix socket path prefixed with "unix:".
+func NewServer(addr string, m *Metrics, logger zerolog.Logger) *Server {
+	return &Server{
+		addr:    addr,
+		metrics: m,
+		perm:    endpoint.Private,
+		logger:  logger.With().Str("component", "metrics_server").Logger(),
+	}
+}
+
+// SetPermissions sets the owner, group and mode of a Unix socket; the
+// default is owner-only access. Call before Start.
+func (s *Server) SetPermissions(perm endpoint.Permissions) {
+	s.perm = perm
+}
+
+// Start begins serving metrics in the background.
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := s.listen()
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler(s.metrics))
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error().Err(err).Msg("metrics server failed")
+		}
+	}()
+
+	s.logger.Info().Str("addr", s.addr).Msg("Metrics server started")
+	return nil
+}
+
+// Stop shuts the server down.
+func (s *Server) Stop() error {
+	if s.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	err := s.server.Shutdown(ctx)
+	if s.socket != "" {
+		if rmErr := s.perm.Remove(s.socket, os.ModeSocket); rmErr != nil {
+			s.logger.Warn().Err(rmErr).Msg("failed to remove metrics socket")
+		}
+	}
+	s.logger.Info().Msg("Metrics server stopped")
+	return err
+}
+
+// Handler returns an http.Handler writing the metrics in OpenMetrics format.
+func Handler(m *Metrics) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		if err := m.Registry().WriteOpenMetrics(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) listen() (net.Listener, error) {
+	path, ok := strings.CutPrefix(s.addr, "unix:")
+	if !ok {
+		listener, err := net.Listen("tcp", s.addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+		}
+		return listener, nil
+	}
+
+	if err := s.perm.Prepare(path, os.ModeSocket); err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	// Removed in Stop, once it has been checked to still be ours
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := s.perm.Apply(path); err != nil {
+		listener.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	s.socket = path
+	return listener, nil
+}
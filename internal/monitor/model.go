@@ -0,0 +1,190 @@
+// Package monitor implements a live terminal view of keystrokes, touchpad
+// contacts and the palm-rejection state, for tuning the cooldown.
+package monitor
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/privacy"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
+)
+
+// historySize is the number of keystrokes and touches kept on screen.
+const historySize = 10
+
+// SuppressionSource reports the palm-rejection state the monitor displays.
+type SuppressionSource interface {
+	IsDisabled() bool
+	CooldownRemaining() time.Duration
+}
+
+// KeyEntry is one key press in the timeline. Label is the key class unless
+// raw key codes were enabled.
+type KeyEntry struct {
+	Time  time.Time
+	Label string
+}
+
+// TouchEntry records a finger landing on a touchpad and whether palm
+// rejection would have swallowed it.
+type TouchEntry struct {
+	Time       time.Time
+	Device     string
+	Suppressed bool
+	// Remaining is the cooldown left when the touch started (if suppressed).
+	Remaining time.Duration
+	// SinceKey is the time since the last key press when the touch started.
+	SinceKey time.Duration
+}
+
+// TouchpadState is the live contact state of one touchpad.
+type TouchpadState struct {
+	Device  string
+	Name    string
+	Fingers int
+	Button  bool
+	X, Y    int32
+}
+
+// Snapshot is a consistent copy of the model for rendering.
+type Snapshot struct {
+	Keys       []KeyEntry
+	Touches    []TouchEntry
+	Touchpads  []TouchpadState
+	Suppressed bool
+	Remaining  time.Duration
+}
+
+// Model accumulates input events into what the monitor shows.
+type Model struct {
+	mu        sync.Mutex
+	source    SuppressionSource
+	policy    privacy.KeyPolicy
+	keys      []KeyEntry
+	touches   []TouchEntry
+	touchpads []*TouchpadState
+	lastKey   time.Time
+}
+
+// NewModel creates a model showing the given touchpads, labelling key
+// presses as policy allows.
+func NewModel(source SuppressionSource, touchpads []*touchpad.DeviceInfo, policy privacy.KeyPolicy) *Model {
+	m := &Model{source: source, policy: policy}
+	for _, dev := range touchpads {
+		m.touchpads = append(m.touchpads, &TouchpadState{Device: filepath.Base(dev.Path), Name: dev.Name})
+	}
+	return m
+}
+
+// HandleKey records a key press. It must be called after the suppression
+// source has seen the same key press.
+func (m *Model) HandleKey(ev *evdev.InputEvent, now time.Time) {
+	if ev.Type != evdev.EV_KEY || ev.Value != 1 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastKey = now
+	m.keys = appendBounded(m.keys, KeyEntry{Time: now, Label: m.policy.Label(ev.Code)})
+}
+
+// HandleTouch updates the contact state of a touchpad.
+func (m *Model) HandleTouch(path string, ev *evdev.InputEvent, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pad := m.touchpad(filepath.Base(path))
+	if pad == nil {
+		return
+	}
+
+	switch ev.Type {
+	case evdev.EV_ABS:
+		switch ev.Code {
+		case evdev.ABS_X:
+			pad.X = ev.Value
+		case evdev.ABS_Y:
+			pad.Y = ev.Value
+		}
+	case evdev.EV_KEY:
+		switch ev.Code {
+		case evdev.BTN_LEFT:
+			pad.Button = ev.Value != 0
+		case evdev.BTN_TOOL_FINGER, evdev.BTN_TOOL_DOUBLETAP, evdev.BTN_TOOL_TRIPLETAP,
+			evdev.BTN_TOOL_QUADTAP, evdev.BTN_TOOL_QUINTTAP:
+			if ev.Value != 0 {
+				pad.Fingers = fingerCount(ev.Code)
+			} else if pad.Fingers == fingerCount(ev.Code) {
+				pad.Fingers = 0
+			}
+		case evdev.BTN_TOUCH:
+			if ev.Value == 1 {
+				entry := TouchEntry{
+					Time:       now,
+					Device:     pad.Device,
+					Suppressed: m.source.IsDisabled(),
+				}
+				if entry.Suppressed {
+					entry.Remaining = m.source.CooldownRemaining()
+				}
+				if !m.lastKey.IsZero() {
+					entry.SinceKey = now.Sub(m.lastKey)
+				}
+				m.touches = appendBounded(m.touches, entry)
+			}
+		}
+	}
+}
+
+// Snapshot returns a copy of the current state.
+func (m *Model) Snapshot() Snapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := Snapshot{
+		Keys:       append([]KeyEntry(nil), m.keys...),
+		Touches:    append([]TouchEntry(nil), m.touches...),
+		Suppressed: m.source.IsDisabled(),
+		Remaining:  m.source.CooldownRemaining(),
+	}
+	for _, pad := range m.touchpads {
+		s.Touchpads = append(s.Touchpads, *pad)
+	}
+	return s
+}
+
+func (m *Model) touchpad(device string) *TouchpadState {
+	for _, pad := range m.touchpads {
+		if pad.Device == device {
+			return pad
+		}
+	}
+	return nil
+}
+
+func fingerCount(code evdev.EvCode) int {
+	switch code {
+	case evdev.BTN_TOOL_FINGER:
+		return 1
+	case evdev.BTN_TOOL_DOUBLETAP:
+		return 2
+	case evdev.BTN_TOOL_TRIPLETAP:
+		return 3
+	case evdev.BTN_TOOL_QUADTAP:
+		return 4
+	case evdev.BTN_TOOL_QUINTTAP:
+		return 5
+	}
+	return 0
+}
+
+func appendBounded[T any](s []T, v T) []T {
+	s = append(s, v)
+	if len(s) > historySize {
+		s = s[len(s)-historySize:]
+	}
+	return s
+}
@@ -0,0 +1,149 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/consumer"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/privacy"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
+)
+
+// DefaultRefresh is how often the view is redrawn.
+const DefaultRefresh = 50 * time.Millisecond
+
+// Options configures a monitor session.
+type Options struct {
+	Keyboard  *touchpad.DeviceInfo
+	Touchpads []*touchpad.DeviceInfo
+	// Settings are the typing detection settings to simulate, as the
+	// daemon would apply them.
+	Settings consumer.Settings
+	// Keys controls whether key codes are shown; by default only key
+	// classes are.
+	Keys    privacy.KeyPolicy
+	Refresh time.Duration
+	Out     io.Writer
+	Logger  zerolog.Logger
+}
+
+// dryRunController tracks the suppression state without grabbing anything,
+// so the monitor never interferes with the touchpad.
+type dryRunController struct {
+	mu       sync.Mutex
+	disabled bool
+}
+
+func (c *dryRunController) Disable() error { c.set(true); return nil }
+func (c *dryRunController) Enable() error  { c.set(false); return nil }
+func (c *dryRunController) Stop() error    { return nil }
+
+func (c *dryRunController) IsDisabled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.disabled
+}
+
+func (c *dryRunController) set(disabled bool) {
+	c.mu.Lock()
+	c.disabled = disabled
+	c.mu.Unlock()
+}
+
+// Run shows the live view until ctx is cancelled. The devices are opened
+// without grabbing; the suppression state is what the daemon's typing
+// detection would do with the same settings.
+func Run(ctx context.Context, opts Options) error {
+	if opts.Refresh <= 0 {
+		opts.Refresh = DefaultRefresh
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	bus := events.NewSystemEventBus(opts.Logger)
+	defer bus.Close()
+	typing := consumer.NewTypingDetectionConsumer(nil, &dryRunController{}, bus, opts.Settings.Cooldown, opts.Logger)
+	typing.ApplySettings(opts.Settings)
+	if err := typing.Start(ctx); err != nil {
+		return err
+	}
+	defer typing.Stop()
+
+	model := NewModel(typing, opts.Touchpads, opts.Keys)
+
+	kb, err := evdev.OpenWithFlags(opts.Keyboard.Path, os.O_RDONLY)
+	if err != nil {
+		return fmt.Errorf("failed to open keyboard device %s: %w", opts.Keyboard.Path, err)
+	}
+	defer kb.Close()
+
+	var pads []*evdev.InputDevice
+	defer func() {
+		for _, pad := range pads {
+			pad.Close()
+		}
+	}()
+	for _, info := range opts.Touchpads {
+		pad, err := evdev.OpenWithFlags(info.Path, os.O_RDONLY)
+		if err != nil {
+			return fmt.Errorf("failed to open touchpad device %s: %w", info.Path, err)
+		}
+		pads = append(pads, pad)
+	}
+
+	errs := make(chan error, 1+len(pads))
+	go readLoop(ctx, kb, errs, func(ev *evdev.InputEvent) {
+		if ev.Type == evdev.EV_KEY {
+			typing.OnKeyEvent(touchpad.KeyEvent{Keyboard: opts.Keyboard.Path, Code: ev.Code, Action: touchpad.KeyAction(ev.Value), Time: time.Now()})
+		}
+		model.HandleKey(ev, time.Now())
+	})
+	for i, pad := range pads {
+		path := opts.Touchpads[i].Path
+		go readLoop(ctx, pad, errs, func(ev *evdev.InputEvent) {
+			model.HandleTouch(path, ev, time.Now())
+		})
+	}
+
+	header := Header{Keyboard: opts.Keyboard.Path, Cooldown: opts.Settings.Cooldown, Off: opts.Settings.Off}
+	io.WriteString(opts.Out, ansiAltScreen)
+	defer io.WriteString(opts.Out, ansiMainScreen)
+
+	ticker := time.NewTicker(opts.Refresh)
+	defer ticker.Stop()
+	for {
+		if err := Render(opts.Out, header, model.Snapshot()); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+// readLoop feeds events from dev to handle until ctx is cancelled or a read fails.
+func readLoop(ctx context.Context, dev *evdev.InputDevice, errs chan<- error, handle func(*evdev.InputEvent)) {
+	for {
+		ev, err := dev.ReadOne()
+		if err != nil {
+			if ctx.Err() == nil {
+				errs <- fmt.Errorf("read %s: %w", dev.Path(), err)
+			}
+			return
+		}
+		handle(ev)
+	}
+}
@@ -0,0 +1,114 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/privacy"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
+)
+
+type fakeSource struct {
+	disabled  bool
+	remaining time.Duration
+}
+
+func (f *fakeSource) IsDisabled() bool                 { return f.disabled }
+func (f *fakeSource) CooldownRemaining() time.Duration { return f.remaining }
+
+func keyEvent(code evdev.EvCode, value int32) *evdev.InputEvent {
+	return &evdev.InputEvent{Type: evdev.EV_KEY, Code: code, Value: value}
+}
+
+func TestModel_TouchVerdicts(t *testing.T) {
+	src := &fakeSource{}
+	m := NewModel(src, []*touchpad.DeviceInfo{{Path: "/dev/input/event7", Name: "ASUF1204:00 Touchpad"}}, privacy.KeyPolicy{})
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	const pad = "/dev/input/event7"
+
+	m.HandleKey(keyEvent(evdev.KEY_A, 1), start)
+	m.HandleKey(keyEvent(evdev.KEY_A, 0), start.Add(20*time.Millisecond))
+
+	src.disabled, src.remaining = true, 200*time.Millisecond
+	m.HandleTouch(pad, keyEvent(evdev.BTN_TOOL_DOUBLETAP, 1), start.Add(100*time.Millisecond))
+	m.HandleTouch(pad, keyEvent(evdev.BTN_TOUCH, 1), start.Add(100*time.Millisecond))
+	m.HandleTouch(pad, &evdev.InputEvent{Type: evdev.EV_ABS, Code: evdev.ABS_X, Value: 640}, start.Add(110*time.Millisecond))
+
+	src.disabled, src.remaining = false, 0
+	m.HandleTouch(pad, keyEvent(evdev.BTN_TOUCH, 0), start.Add(400*time.Millisecond))
+	m.HandleTouch(pad, keyEvent(evdev.BTN_TOUCH, 1), start.Add(500*time.Millisecond))
+	m.HandleTouch("/dev/input/event99", keyEvent(evdev.BTN_TOUCH, 1), start.Add(500*time.Millisecond))
+
+	s := m.Snapshot()
+	require.Len(t, s.Keys, 1, "only key presses are recorded")
+	assert.Equal(t, "letter", s.Keys[0].Label, "key codes are redacted by default")
+
+	require.Len(t, s.Touches, 2, "unknown devices are ignored")
+	assert.True(t, s.Touches[0].Suppressed)
+	assert.Equal(t, 200*time.Millisecond, s.Touches[0].Remaining)
+	assert.Equal(t, 100*time.Millisecond, s.Touches[0].SinceKey)
+	assert.False(t, s.Touches[1].Suppressed)
+	assert.Equal(t, 500*time.Millisecond, s.Touches[1].SinceKey)
+
+	require.Len(t, s.Touchpads, 1)
+	assert.Equal(t, "event7", s.Touchpads[0].Device)
+	assert.Equal(t, 2, s.Touchpads[0].Fingers)
+	assert.Equal(t, int32(640), s.Touchpads[0].X)
+}
+
+func TestModel_HistoryIsBounded(t *testing.T) {
+	m := NewModel(&fakeSource{}, nil, privacy.KeyPolicy{})
+	now := time.Now()
+	for i := 0; i < historySize+5; i++ {
+		m.HandleKey(keyEvent(evdev.KEY_B, 1), now.Add(time.Duration(i)*time.Millisecond))
+	}
+	s := m.Snapshot()
+	require.Len(t, s.Keys, historySize)
+	assert.Equal(t, now.Add(5*time.Millisecond), s.Keys[0].Time)
+}
+
+func TestModel_RawKeyCodes(t *testing.T) {
+	m := NewModel(&fakeSource{}, nil, privacy.KeyPolicy{RawCodes: true})
+	m.HandleKey(keyEvent(evdev.KEY_A, 1), time.Now())
+	s := m.Snapshot()
+	require.Len(t, s.Keys, 1)
+	assert.Equal(t, "KEY_A", s.Keys[0].Label)
+}
+
+func TestRender(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := Snapshot{
+		Keys: []KeyEntry{
+			{Time: start, Label: "KEY_A"},
+			{Time: start.Add(80 * time.Millisecond), Label: "KEY_S"},
+		},
+		Touches: []TouchEntry{
+			{Time: start.Add(150 * time.Millisecond), Device: "event7", Suppressed: true, Remaining: 230 * time.Millisecond, SinceKey: 70 * time.Millisecond},
+		},
+		Touchpads:  []TouchpadState{{Device: "event7", Name: "Touchpad", Fingers: 1}},
+		Suppressed: true,
+		Remaining:  150 * time.Millisecond,
+	}
+
+	var b strings.Builder
+	require.NoError(t, Render(&b, Header{Keyboard: "/dev/input/event3", Cooldown: 300 * time.Millisecond}, s))
+	out := b.String()
+
+	assert.Contains(t, out, "cooldown 300ms")
+	assert.Contains(t, out, "SUPPRESSED")
+	assert.Contains(t, out, "["+strings.Repeat("#", barWidth/2)+strings.Repeat(".", barWidth/2)+"]  150ms left")
+	assert.Contains(t, out, "KEY_S            +80ms")
+	assert.Contains(t, out, "(230ms of cooldown left), 70ms after last key")
+	assert.Contains(t, out, "fingers 1")
+}
+
+func TestRender_Off(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, Render(&b, Header{Keyboard: "/dev/input/event3", Cooldown: time.Second, Off: true}, Snapshot{}))
+	assert.Contains(t, b.String(), "(palm rejection off)")
+}
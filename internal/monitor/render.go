@@ -0,0 +1,87 @@
+package monitor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	ansiClear      = "\x1b[H\x1b[2J"
+	ansiAltScreen  = "\x1b[?1049h\x1b[?25l"
+	ansiMainScreen = "\x1b[?25h\x1b[?1049l"
+	ansiRed        = "\x1b[31m"
+	ansiGreen      = "\x1b[32m"
+	ansiReset      = "\x1b[0m"
+	barWidth       = 30
+	timeLayout     = "15:04:05.000"
+)
+
+// Header describes the monitored devices and settings.
+type Header struct {
+	Keyboard string
+	Cooldown time.Duration
+	// Off is set when the simulated settings turn palm rejection off
+	Off bool
+}
+
+// Render writes one frame of the monitor view.
+func Render(w io.Writer, h Header, s Snapshot) error {
+	var b strings.Builder
+	b.WriteString(ansiClear)
+	fmt.Fprintf(&b, "palm-reject monitor  cooldown %s  keyboard %s  (Ctrl+C to quit)\n\n", h.Cooldown, h.Keyboard)
+
+	if s.Suppressed {
+		filled := 0
+		if h.Cooldown > 0 {
+			filled = int(float64(barWidth) * float64(s.Remaining) / float64(h.Cooldown))
+		}
+		fmt.Fprintf(&b, "Touchpad: %sSUPPRESSED%s  [%s%s]  %s left\n",
+			ansiRed, ansiReset,
+			strings.Repeat("#", filled), strings.Repeat(".", barWidth-filled),
+			s.Remaining.Round(time.Millisecond))
+	} else if h.Off {
+		fmt.Fprintf(&b, "Touchpad: %sactive%s  (palm rejection off)\n", ansiGreen, ansiReset)
+	} else {
+		fmt.Fprintf(&b, "Touchpad: %sactive%s\n", ansiGreen, ansiReset)
+	}
+
+	b.WriteString("\nContacts:\n")
+	for _, pad := range s.Touchpads {
+		button := "up"
+		if pad.Button {
+			button = "down"
+		}
+		fmt.Fprintf(&b, "  %-8s %-32s fingers %d  button %-4s  x %5d  y %5d\n",
+			pad.Device, pad.Name, pad.Fingers, button, pad.X, pad.Y)
+	}
+
+	b.WriteString("\nKeystrokes:\n")
+	var prev time.Time
+	for _, k := range s.Keys {
+		gap := ""
+		if !prev.IsZero() {
+			gap = fmt.Sprintf("+%s", k.Time.Sub(prev).Round(time.Millisecond))
+		}
+		fmt.Fprintf(&b, "  %s  %-16s %s\n", k.Time.Format(timeLayout), k.Label, gap)
+		prev = k.Time
+	}
+
+	b.WriteString("\nTouches:\n")
+	for _, t := range s.Touches {
+		verdict := ansiGreen + "passed" + ansiReset
+		if t.Suppressed {
+			verdict = fmt.Sprintf("%ssuppressed%s (%s of cooldown left)",
+				ansiRed, ansiReset, t.Remaining.Round(time.Millisecond))
+		}
+		since := "no key pressed yet"
+		if t.SinceKey > 0 {
+			since = fmt.Sprintf("%s after last key", t.SinceKey.Round(time.Millisecond))
+		}
+		fmt.Fprintf(&b, "  %s  %-8s %s, %s\n", t.Time.Format(timeLayout), t.Device, verdict, since)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -6,20 +6,24 @@ import (
     "os"
     "strings"
     "syscall"
-    "time"
 
     "github.com/rs/zerolog"
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/endpoint"
     "github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+    "github.com/artonio/zenbook-duo-palm-rejection/internal/metrics"
 )
 
-const DefaultPipePath = "/tmp/zenbook-duo-daemon.pipe"
+const DefaultPipePath = endpoint.DefaultDir + "/daemon.pipe"
 
 type Receiver struct {
     ctx            context.Context
     cancel         context.CancelFunc
     path           string
+    file           *os.File
+    perm           endpoint.Permissions
     systemEventBus *events.SystemEventBus
     logger         zerolog.Logger
+    metrics        *metrics.Metrics
 }
 
 func NewReceiver(path string, bus *events.SystemEventBus, logger zerolog.Logger) *Receiver {
@@ -28,17 +32,44 @@ func NewReceiver(path string, bus *events.SystemEventBus, logger zerolog.Logger)
     }
     return &Receiver{
         path:           path,
+        perm:           endpoint.Private,
         systemEventBus: bus,
         logger:         logger.With().Str("component", "pipe_receiver").Logger(),
     }
 }
 
+// SetMetrics enables counting of received commands. Call before Start.
+func (r *Receiver) SetMetrics(m *metrics.Metrics) {
+    r.metrics = m
+}
+
+// SetPermissions sets the owner, group and mode of the pipe; the default is
+// endpoint.Private. A pipe cannot tell who wrote to it, so this is the only
+// access control it has. Call before Start.
+func (r *Receiver) SetPermissions(perm endpoint.Permissions) {
+    r.perm = perm
+}
+
 func (r *Receiver) Start(ctx context.Context) error {
     r.ctx, r.cancel = context.WithCancel(ctx)
-    os.Remove(r.path)
-    if err := syscall.Mkfifo(r.path, 0622); err != nil {
+    if err := r.perm.Prepare(r.path, os.ModeNamedPipe); err != nil {
+        return err
+    }
+    if err := syscall.Mkfifo(r.path, 0600); err != nil {
+        return err
+    }
+    if err := r.perm.Apply(r.path); err != nil {
+        os.Remove(r.path)
+        return err
+    }
+    // Opened read-write once, so the pipe never sees EOF between writers
+    // and stays usable after the daemon drops root
+    file, err := os.OpenFile(r.path, os.O_RDWR, os.ModeNamedPipe)
+    if err != nil {
+        os.Remove(r.path)
         return err
     }
+    r.file = file
     go r.readLoop()
     r.logger.Info().Str("path", r.path).Msg("Pipe receiver started")
     return nil
@@ -48,32 +79,17 @@ func (r *Receiver) Stop() error {
     if r.cancel != nil {
         r.cancel()
     }
-    os.Remove(r.path)
+    if r.file != nil {
+        r.file.Close()
+    }
+    if err := r.perm.Remove(r.path, os.ModeNamedPipe); err != nil {
+        r.logger.Warn().Err(err).Msg("failed to remove pipe")
+    }
     return nil
 }
 
 func (r *Receiver) readLoop() {
-    for {
-        select {
-        case <-r.ctx.Done():
-            return
-        default:
-            r.readOnce()
-        }
-    }
-}
-
-func (r *Receiver) readOnce() {
-    file, err := os.OpenFile(r.path, os.O_RDONLY, os.ModeNamedPipe)
-    if err != nil {
-        if r.ctx.Err() == nil {
-            r.logger.Error().Err(err).Msg("failed to open pipe")
-            time.Sleep(time.Second)
-        }
-        return
-    }
-    defer file.Close()
-    scanner := bufio.NewScanner(file)
+    scanner := bufio.NewScanner(r.file)
     for scanner.Scan() {
         select {
         case <-r.ctx.Done():
@@ -82,6 +98,9 @@ func (r *Receiver) readOnce() {
             r.handleCommand(scanner.Text())
         }
     }
+    if err := scanner.Err(); err != nil && r.ctx.Err() == nil {
+        r.logger.Error().Err(err).Msg("failed to read pipe")
+    }
 }
 
 func (r *Receiver) handleCommand(cmd string) {
@@ -90,14 +109,12 @@ func (r *Receiver) handleCommand(cmd string) {
         return
     }
     r.logger.Info().Str("command", cmd).Msg("pipe command received")
-    switch cmd {
-    case "touchpad_disable":
-        r.systemEventBus.Publish(events.TouchpadDisable)
-    case "touchpad_enable":
-        r.systemEventBus.Publish(events.TouchpadEnable)
-    case "touchpad_toggle":
-        r.systemEventBus.Publish(events.TouchpadToggle)
-    default:
+    event, ok := events.CommandEvent(cmd)
+    if !ok {
+        r.metrics.ObserveCommand("pipe", "unknown")
         r.logger.Warn().Str("command", cmd).Msg("unknown pipe command")
+        return
     }
+    r.metrics.ObserveCommand("pipe", cmd)
+    r.systemEventBus.Publish(event)
 }
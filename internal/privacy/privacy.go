@@ -0,0 +1,130 @@
+// Package privacy keeps what the user types out of logs, recordings and
+// metrics. The daemon only needs to know that a key was pressed and when;
+// by default everything it records about a key press is the key's class
+// (letter, digit, modifier, ...) and its timing. Raw key codes are only
+// recorded after an explicit opt-in, for debugging keyboard detection.
+package privacy
+
+import (
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+	"github.com/rs/zerolog"
+)
+
+// KeyClass is a coarse category of keys that does not reveal which key was
+// pressed within the category.
+type KeyClass string
+
+const (
+	ClassLetter      KeyClass = "letter"
+	ClassDigit       KeyClass = "digit"
+	ClassPunctuation KeyClass = "punctuation"
+	ClassWhitespace  KeyClass = "whitespace"
+	ClassEditing     KeyClass = "editing"
+	ClassModifier    KeyClass = "modifier"
+	ClassNavigation  KeyClass = "navigation"
+	ClassFunction    KeyClass = "function"
+	ClassKeypad      KeyClass = "keypad"
+	ClassOther       KeyClass = "other"
+)
+
+var keyClasses = map[evdev.EvCode]KeyClass{}
+
+func init() {
+	classes := map[KeyClass][]evdev.EvCode{
+		ClassLetter: {
+			evdev.KEY_A, evdev.KEY_B, evdev.KEY_C, evdev.KEY_D, evdev.KEY_E, evdev.KEY_F,
+			evdev.KEY_G, evdev.KEY_H, evdev.KEY_I, evdev.KEY_J, evdev.KEY_K, evdev.KEY_L,
+			evdev.KEY_M, evdev.KEY_N, evdev.KEY_O, evdev.KEY_P, evdev.KEY_Q, evdev.KEY_R,
+			evdev.KEY_S, evdev.KEY_T, evdev.KEY_U, evdev.KEY_V, evdev.KEY_W, evdev.KEY_X,
+			evdev.KEY_Y, evdev.KEY_Z,
+		},
+		ClassDigit: {
+			evdev.KEY_1, evdev.KEY_2, evdev.KEY_3, evdev.KEY_4, evdev.KEY_5,
+			evdev.KEY_6, evdev.KEY_7, evdev.KEY_8, evdev.KEY_9, evdev.KEY_0,
+		},
+		ClassPunctuation: {
+			evdev.KEY_MINUS, evdev.KEY_EQUAL, evdev.KEY_LEFTBRACE, evdev.KEY_RIGHTBRACE,
+			evdev.KEY_SEMICOLON, evdev.KEY_APOSTROPHE, evdev.KEY_GRAVE, evdev.KEY_BACKSLASH,
+			evdev.KEY_COMMA, evdev.KEY_DOT, evdev.KEY_SLASH, evdev.KEY_102ND,
+		},
+		ClassWhitespace: {evdev.KEY_SPACE, evdev.KEY_TAB, evdev.KEY_ENTER},
+		ClassEditing:    {evdev.KEY_BACKSPACE, evdev.KEY_DELETE, evdev.KEY_INSERT},
+		ClassModifier: {
+			evdev.KEY_LEFTSHIFT, evdev.KEY_RIGHTSHIFT, evdev.KEY_LEFTCTRL, evdev.KEY_RIGHTCTRL,
+			evdev.KEY_LEFTALT, evdev.KEY_RIGHTALT, evdev.KEY_LEFTMETA, evdev.KEY_RIGHTMETA,
+			evdev.KEY_CAPSLOCK, evdev.KEY_NUMLOCK, evdev.KEY_SCROLLLOCK, evdev.KEY_COMPOSE,
+		},
+		ClassNavigation: {
+			evdev.KEY_UP, evdev.KEY_DOWN, evdev.KEY_LEFT, evdev.KEY_RIGHT,
+			evdev.KEY_HOME, evdev.KEY_END, evdev.KEY_PAGEUP, evdev.KEY_PAGEDOWN,
+		},
+		ClassFunction: {
+			evdev.KEY_ESC, evdev.KEY_F1, evdev.KEY_F2, evdev.KEY_F3, evdev.KEY_F4,
+			evdev.KEY_F5, evdev.KEY_F6, evdev.KEY_F7, evdev.KEY_F8, evdev.KEY_F9,
+			evdev.KEY_F10, evdev.KEY_F11, evdev.KEY_F12, evdev.KEY_SYSRQ, evdev.KEY_PAUSE,
+		},
+		ClassKeypad: {
+			evdev.KEY_KP0, evdev.KEY_KP1, evdev.KEY_KP2, evdev.KEY_KP3, evdev.KEY_KP4,
+			evdev.KEY_KP5, evdev.KEY_KP6, evdev.KEY_KP7, evdev.KEY_KP8, evdev.KEY_KP9,
+			evdev.KEY_KPDOT, evdev.KEY_KPENTER, evdev.KEY_KPPLUS, evdev.KEY_KPMINUS,
+			evdev.KEY_KPASTERISK, evdev.KEY_KPSLASH,
+		},
+	}
+	for class, codes := range classes {
+		for _, code := range codes {
+			keyClasses[code] = class
+		}
+	}
+}
+
+// Classify returns the class of a key code.
+func Classify(code evdev.EvCode) KeyClass {
+	if class, ok := keyClasses[code]; ok {
+		return class
+	}
+	return ClassOther
+}
+
+// KeyPolicy decides how much of a key press may be recorded. The zero
+// value is the private default.
+type KeyPolicy struct {
+	// RawCodes records the key code itself. It turns debug logs and the
+	// monitor into a keylogger and must only be set on explicit request.
+	RawCodes bool
+}
+
+// Label describes a key for display: its class, or the key name when raw
+// codes are enabled.
+func (p KeyPolicy) Label(code evdev.EvCode) string {
+	if p.RawCodes {
+		return evdev.CodeName(evdev.EV_KEY, code)
+	}
+	return string(Classify(code))
+}
+
+// LogKey adds the loggable fields of a key press to e: the key class, the
+// time since the previous key press (0 for the first) and, only with
+// RawCodes, the key code and name.
+func (p KeyPolicy) LogKey(e *zerolog.Event, code evdev.EvCode, sinceLast time.Duration) *zerolog.Event {
+	e = e.Str("key_class", string(Classify(code))).Dur("since_last_key", sinceLast)
+	if p.RawCodes {
+		e = e.Uint16("code", uint16(code)).Str("key", evdev.CodeName(evdev.EV_KEY, code))
+	}
+	return e
+}
+
+// Guarantee describes what the daemon records about key presses under p,
+// for the doctor report.
+func (p KeyPolicy) Guarantee() []string {
+	lines := []string{
+		"key presses are recorded only as a class (letter, digit, modifier, ...) and their timing",
+		"metrics count key presses without codes or classes",
+		"key codes are never written to the control socket, pipe or metrics exporter",
+	}
+	if p.RawCodes {
+		lines[0] = "log_key_codes is enabled: debug logs and the monitor include raw key codes"
+	}
+	return lines
+}
@@ -0,0 +1,53 @@
+package privacy
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		code evdev.EvCode
+		want KeyClass
+	}{
+		{evdev.KEY_Q, ClassLetter},
+		{evdev.KEY_7, ClassDigit},
+		{evdev.KEY_SEMICOLON, ClassPunctuation},
+		{evdev.KEY_SPACE, ClassWhitespace},
+		{evdev.KEY_BACKSPACE, ClassEditing},
+		{evdev.KEY_LEFTSHIFT, ClassModifier},
+		{evdev.KEY_PAGEDOWN, ClassNavigation},
+		{evdev.KEY_F5, ClassFunction},
+		{evdev.KEY_KP3, ClassKeypad},
+		{evdev.KEY_MUTE, ClassOther},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Classify(tt.code), evdev.CodeName(evdev.EV_KEY, tt.code))
+	}
+}
+
+func TestKeyPolicy_LogKey(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+
+	KeyPolicy{}.LogKey(logger.Debug(), evdev.KEY_P, 120*time.Millisecond).Msg("Key press detected")
+	assert.Contains(t, buf.String(), `"key_class":"letter"`)
+	assert.Contains(t, buf.String(), `"since_last_key":120`)
+	assert.NotContains(t, buf.String(), `"code"`)
+	assert.NotContains(t, buf.String(), "KEY_P")
+
+	buf.Reset()
+	KeyPolicy{RawCodes: true}.LogKey(logger.Debug(), evdev.KEY_P, 0).Msg("Key press detected")
+	assert.Contains(t, buf.String(), `"code":25`)
+	assert.Contains(t, buf.String(), `"key":"KEY_P"`)
+}
+
+func TestKeyPolicy_Label(t *testing.T) {
+	assert.Equal(t, "digit", KeyPolicy{}.Label(evdev.KEY_4))
+	assert.Equal(t, "KEY_4", KeyPolicy{RawCodes: true}.Label(evdev.KEY_4))
+}
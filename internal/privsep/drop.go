@@ -0,0 +1,201 @@
+package privsep
+
+import (
+	"errors"
+	"fmt"
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	prSetKeepCaps     = 8
+	prSetNoNewPrivs   = 38
+	prGetNoNewPrivs   = 39
+	capVersion3       = 0x20080522
+	capLastSupported  = 40
+	capabilitiesWords = 2
+)
+
+// capabilityNames maps capability names to their numbers, from
+// linux/capability.h.
+var capabilityNames = map[string]uint{
+	"CAP_CHOWN": 0, "CAP_DAC_OVERRIDE": 1, "CAP_DAC_READ_SEARCH": 2, "CAP_FOWNER": 3,
+	"CAP_FSETID": 4, "CAP_KILL": 5, "CAP_SETGID": 6, "CAP_SETUID": 7, "CAP_SETPCAP": 8,
+	"CAP_LINUX_IMMUTABLE": 9, "CAP_NET_BIND_SERVICE": 10, "CAP_NET_BROADCAST": 11,
+	"CAP_NET_ADMIN": 12, "CAP_NET_RAW": 13, "CAP_IPC_LOCK": 14, "CAP_IPC_OWNER": 15,
+	"CAP_SYS_MODULE": 16, "CAP_SYS_RAWIO": 17, "CAP_SYS_CHROOT": 18, "CAP_SYS_PTRACE": 19,
+	"CAP_SYS_PACCT": 20, "CAP_SYS_ADMIN": 21, "CAP_SYS_BOOT": 22, "CAP_SYS_NICE": 23,
+	"CAP_SYS_RESOURCE": 24, "CAP_SYS_TIME": 25, "CAP_SYS_TTY_CONFIG": 26, "CAP_MKNOD": 27,
+	"CAP_LEASE": 28, "CAP_AUDIT_WRITE": 29, "CAP_AUDIT_CONTROL": 30, "CAP_SETFCAP": 31,
+	"CAP_MAC_OVERRIDE": 32, "CAP_MAC_ADMIN": 33, "CAP_SYSLOG": 34, "CAP_WAKE_ALARM": 35,
+	"CAP_BLOCK_SUSPEND": 36, "CAP_AUDIT_READ": 37, "CAP_PERFMON": 38, "CAP_BPF": 39,
+	"CAP_CHECKPOINT_RESTORE": 40,
+}
+
+// ParseCapability returns the number of a capability given as
+// "CAP_DAC_OVERRIDE" or "dac_override".
+func ParseCapability(name string) (uint, error) {
+	upper := strings.ToUpper(name)
+	if !strings.HasPrefix(upper, "CAP_") {
+		upper = "CAP_" + upper
+	}
+	if c, ok := capabilityNames[upper]; ok {
+		return c, nil
+	}
+	return 0, fmt.Errorf("unknown capability %q", name)
+}
+
+// ParseCapabilities parses the capabilities to keep after dropping root.
+// CAP_SETUID and CAP_SETGID are refused: they would allow regaining root.
+func ParseCapabilities(names []string) ([]uint, error) {
+	var caps []uint
+	var errs []error
+	for _, name := range names {
+		c, err := ParseCapability(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if isSetID(c) {
+			errs = append(errs, fmt.Errorf("%s would allow regaining root", name))
+			continue
+		}
+		caps = append(caps, c)
+	}
+	return caps, errors.Join(errs...)
+}
+
+func isSetID(c uint) bool {
+	return c == capabilityNames["CAP_SETUID"] || c == capabilityNames["CAP_SETGID"]
+}
+
+// Credentials is the identity to drop to.
+type Credentials struct {
+	User   string
+	UID    int
+	GID    int
+	Groups []int
+}
+
+// Lookup resolves a user and the supplementary groups to keep, e.g.
+// "input" so hot-plugged event nodes can still be opened directly.
+func Lookup(name string, groups []string) (Credentials, error) {
+	u, err := user.Lookup(name)
+	if err != nil {
+		return Credentials{}, err
+	}
+	creds := Credentials{User: name}
+	if creds.UID, err = strconv.Atoi(u.Uid); err != nil {
+		return Credentials{}, fmt.Errorf("user %s has non-numeric uid %q", name, u.Uid)
+	}
+	if creds.GID, err = strconv.Atoi(u.Gid); err != nil {
+		return Credentials{}, fmt.Errorf("user %s has non-numeric gid %q", name, u.Gid)
+	}
+	if creds.UID == 0 {
+		return Credentials{}, fmt.Errorf("user %s is root", name)
+	}
+	for _, name := range groups {
+		g, err := user.LookupGroup(name)
+		if err != nil {
+			return Credentials{}, err
+		}
+		gid, err := strconv.Atoi(g.Gid)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("group %s has non-numeric gid %q", name, g.Gid)
+		}
+		creds.Groups = append(creds.Groups, gid)
+	}
+	return creds, nil
+}
+
+type capHeader struct {
+	version uint32
+	pid     int32
+}
+
+type capData struct {
+	effective   uint32
+	permitted   uint32
+	inheritable uint32
+}
+
+// Drop switches every thread of the process to creds, keeping only the
+// capabilities in keep, and sets no_new_privs so nothing executed later
+// can regain privileges. Open descriptors stay usable.
+//
+// no_new_privs and capabilities are per thread and can only be set on all
+// of them through AllThreadsSyscall, which a binary linked with cgo does
+// not support; Drop then fails before changing anything.
+func Drop(creds Credentials, keep []uint) error {
+	if err := checkAllThreads(); err != nil {
+		return err
+	}
+	for _, c := range keep {
+		if isSetID(c) {
+			return errors.New("keeping CAP_SETUID or CAP_SETGID would allow regaining root")
+		}
+	}
+	if len(keep) > 0 {
+		// Keep the permitted set across setuid; trimmed below
+		if _, _, errno := syscall.AllThreadsSyscall(syscall.SYS_PRCTL, prSetKeepCaps, 1, 0); errno != 0 {
+			return fmt.Errorf("failed to keep capabilities: %w", capErr(errno))
+		}
+	}
+
+	if err := syscall.Setgroups(creds.Groups); err != nil {
+		return fmt.Errorf("failed to set groups: %w", err)
+	}
+	if err := syscall.Setgid(creds.GID); err != nil {
+		return fmt.Errorf("failed to set gid %d: %w", creds.GID, err)
+	}
+	if err := syscall.Setuid(creds.UID); err != nil {
+		return fmt.Errorf("failed to set uid %d: %w", creds.UID, err)
+	}
+
+	if len(keep) > 0 {
+		var data [capabilitiesWords]capData
+		for _, c := range keep {
+			if c > capLastSupported {
+				return fmt.Errorf("capability %d out of range", c)
+			}
+			data[c/32].effective |= 1 << (c % 32)
+			data[c/32].permitted |= 1 << (c % 32)
+		}
+		hdr := capHeader{version: capVersion3}
+		if _, _, errno := syscall.AllThreadsSyscall(syscall.SYS_CAPSET, uintptr(unsafe.Pointer(&hdr)), uintptr(unsafe.Pointer(&data[0])), 0); errno != 0 {
+			return fmt.Errorf("failed to set capabilities: %w", capErr(errno))
+		}
+		if _, _, errno := syscall.AllThreadsSyscall(syscall.SYS_PRCTL, prSetKeepCaps, 0, 0); errno != 0 {
+			return fmt.Errorf("failed to reset keep-capabilities: %w", capErr(errno))
+		}
+	}
+
+	if _, _, errno := syscall.AllThreadsSyscall(syscall.SYS_PRCTL, prSetNoNewPrivs, 1, 0); errno != 0 {
+		return fmt.Errorf("failed to set no_new_privs: %w", capErr(errno))
+	}
+
+	// Make sure there is no way back
+	if err := syscall.Setuid(0); err == nil {
+		return errors.New("still able to regain root after dropping privileges")
+	}
+	return nil
+}
+
+// checkAllThreads reports whether AllThreadsSyscall works, with a harmless
+// PR_GET_NO_NEW_PRIVS.
+func checkAllThreads() error {
+	if _, _, errno := syscall.AllThreadsSyscall(syscall.SYS_PRCTL, prGetNoNewPrivs, 0, 0); errno != 0 {
+		return fmt.Errorf("cannot drop privileges: %w", capErr(errno))
+	}
+	return nil
+}
+
+func capErr(errno syscall.Errno) error {
+	if errno == syscall.ENOTSUP {
+		return fmt.Errorf("%w (binary built with cgo; build with CGO_ENABLED=0)", errno)
+	}
+	return errno
+}
@@ -0,0 +1,286 @@
+package privsep
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// HelperCommand is the hidden subcommand that runs the helper; the daemon
+// re-executes itself with it.
+const HelperCommand = "privsep-helper"
+
+// helperFD is the descriptor the helper finds its end of the socketpair on.
+const helperFD = 3
+
+// maxMessage bounds a request or response.
+const maxMessage = 4096
+
+// oPath is O_PATH, which package syscall lacks.
+const oPath = 0x200000
+
+// dialRequest is the flag of a request to connect to a Unix socket
+// instead of opening a file.
+const dialRequest = -1
+
+// userRuntimeDir holds the users' runtime directories; tests point it at
+// a temporary directory.
+var userRuntimeDir = "/run/user"
+
+var (
+	ipcSocket   = regexp.MustCompile(`^([0-9]+)/(sway-ipc\.[0-9]+\.[0-9]+\.sock|i3/ipc-socket\.[0-9]+)$`)
+	inputNode   = regexp.MustCompile(`^/dev/input/event[0-9]+$`)
+	hidrawNode  = regexp.MustCompile(`^/dev/hidraw[0-9]+$`)
+	uinputNode  = "/dev/uinput"
+	ledFile     = regexp.MustCompile(`^/sys/class/leds/[^/]+/brightness$`)
+	accessModes = syscall.O_RDONLY | syscall.O_WRONLY | syscall.O_RDWR
+)
+
+// checkPath is the helper's allow-list: it only opens input event nodes,
+// hidraw nodes, /dev/uinput (for the virtual devices of the clicks and taps
+// suppressions) and LED brightness files, never creates or truncates
+// anything, and refuses symlinks in place of device nodes. It only
+// connects to sway and i3 IPC sockets; see dialSocket.
+func checkPath(path string, flag int) error {
+	if path != filepath.Clean(path) {
+		return fmt.Errorf("path %q is not clean", path)
+	}
+	if flag == dialRequest {
+		_, err := socketOwner(path)
+		return err
+	}
+	if flag&^(accessModes|syscall.O_NONBLOCK) != 0 {
+		return fmt.Errorf("flags %#x not allowed", flag)
+	}
+	access := flag & accessModes
+
+	switch {
+	case inputNode.MatchString(path), hidrawNode.MatchString(path), path == uinputNode:
+		if hidrawNode.MatchString(path) && access != syscall.O_RDONLY {
+			return fmt.Errorf("%s may only be opened read-only", path)
+		}
+		if path == uinputNode && access != syscall.O_WRONLY {
+			return fmt.Errorf("%s may only be opened write-only", path)
+		}
+		info, err := os.Lstat(path)
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeCharDevice == 0 || info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("%s is not a character device", path)
+		}
+		return nil
+	case ledFile.MatchString(path):
+		// /sys/class/leds entries are symlinks into /sys/devices
+		resolved, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(resolved, "/sys/devices/") || filepath.Base(resolved) != "brightness" {
+			return fmt.Errorf("%s does not resolve to an LED", path)
+		}
+		if access != syscall.O_WRONLY {
+			return fmt.Errorf("%s may only be opened write-only", path)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s is not on the helper's allow-list", path)
+}
+
+// Client talks to the helper.
+type Client struct {
+	mu   sync.Mutex
+	conn *os.File
+	cmd  *exec.Cmd
+}
+
+// StartHelper starts the helper, running the current executable with
+// HelperCommand, while the daemon still has the privileges to open devices.
+func StartHelper() (*Client, error) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_SEQPACKET|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create socketpair: %w", err)
+	}
+	parent := os.NewFile(uintptr(fds[0]), "privsep")
+	child := os.NewFile(uintptr(fds[1]), "privsep-helper")
+	defer child.Close()
+
+	exe, err := os.Executable()
+	if err != nil {
+		parent.Close()
+		return nil, err
+	}
+	cmd := exec.Command(exe, HelperCommand)
+	cmd.ExtraFiles = []*os.File{child} // becomes helperFD
+	cmd.Stderr = os.Stderr
+	cmd.Env = []string{}
+	if err := cmd.Start(); err != nil {
+		parent.Close()
+		return nil, fmt.Errorf("failed to start privilege helper: %w", err)
+	}
+	return &Client{conn: parent, cmd: cmd}, nil
+}
+
+// Open asks the helper to open path.
+func (c *Client) Open(path string, flag int) (*os.File, error) {
+	return c.request(path, flag)
+}
+
+// Dial asks the helper to connect to the Unix socket at path.
+func (c *Client) Dial(path string) (*os.File, error) {
+	return c.request(path, dialRequest)
+}
+
+func (c *Client) request(path string, flag int) (*os.File, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := syscall.Sendmsg(int(c.conn.Fd()), []byte(strconv.Itoa(flag)+" "+path), nil, nil, 0); err != nil {
+		return nil, fmt.Errorf("privilege helper: %w", err)
+	}
+
+	buf := make([]byte, maxMessage)
+	oob := make([]byte, syscall.CmsgSpace(4))
+	n, oobn, _, _, err := syscall.Recvmsg(int(c.conn.Fd()), buf, oob, syscall.MSG_CMSG_CLOEXEC)
+	if err != nil {
+		return nil, fmt.Errorf("privilege helper: %w", err)
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("privilege helper: %w", io.EOF)
+	}
+	reply := string(buf[:n])
+	if reply != "ok" {
+		return nil, fmt.Errorf("privilege helper: %s", strings.TrimPrefix(reply, "error "))
+	}
+
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil || len(msgs) != 1 {
+		return nil, errors.New("privilege helper: no descriptor received")
+	}
+	fds, err := syscall.ParseUnixRights(&msgs[0])
+	if err != nil || len(fds) != 1 {
+		return nil, errors.New("privilege helper: no descriptor received")
+	}
+	return os.NewFile(uintptr(fds[0]), path), nil
+}
+
+// Stop stops the helper.
+func (c *Client) Stop() error {
+	err := c.conn.Close()
+	if c.cmd != nil {
+		// The helper exits when its end of the socketpair is closed
+		c.cmd.Wait()
+	}
+	return err
+}
+
+// ServeHelper runs the helper side until the daemon closes the socketpair.
+// It is the entire body of the HelperCommand process.
+func ServeHelper() error {
+	conn := os.NewFile(helperFD, "privsep")
+	defer conn.Close()
+	return serve(conn, checkPath)
+}
+
+func serve(conn *os.File, allow func(path string, flag int) error) error {
+	buf := make([]byte, maxMessage)
+	for {
+		n, _, _, _, err := syscall.Recvmsg(int(conn.Fd()), buf, nil, 0)
+		if err != nil {
+			if errors.Is(err, syscall.EINTR) {
+				continue
+			}
+			return err
+		}
+		if n == 0 {
+			return nil // daemon went away
+		}
+
+		f, err := handleRequest(string(buf[:n]), allow)
+		if err != nil {
+			if err := syscall.Sendmsg(int(conn.Fd()), []byte("error "+err.Error()), nil, nil, 0); err != nil {
+				return err
+			}
+			continue
+		}
+		err = syscall.Sendmsg(int(conn.Fd()), []byte("ok"), syscall.UnixRights(int(f.Fd())), nil, 0)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func handleRequest(req string, allow func(path string, flag int) error) (*os.File, error) {
+	flagStr, path, ok := strings.Cut(req, " ")
+	if !ok {
+		return nil, errors.New("malformed request")
+	}
+	flag, err := strconv.Atoi(flagStr)
+	if err != nil {
+		return nil, errors.New("malformed request")
+	}
+	if err := allow(path, flag); err != nil {
+		return nil, err
+	}
+	if flag == dialRequest {
+		return dialSocket(path)
+	}
+	return os.OpenFile(path, flag|syscall.O_NOFOLLOW|syscall.O_CLOEXEC, 0)
+}
+
+// socketOwner returns the user whose compositor IPC socket path is: a
+// sway or i3 socket in a user's runtime directory, never root's.
+func socketOwner(path string) (int, error) {
+	rel, ok := strings.CutPrefix(path, userRuntimeDir+"/")
+	m := ipcSocket.FindStringSubmatch(rel)
+	if !ok || m == nil {
+		return 0, fmt.Errorf("%s is not on the helper's allow-list", path)
+	}
+	uid, err := strconv.Atoi(m[1])
+	if err != nil || uid == 0 {
+		return 0, fmt.Errorf("%s is not on the helper's allow-list", path)
+	}
+	return uid, nil
+}
+
+// dialSocket connects to the IPC socket at path. The socket is pinned with
+// an O_PATH descriptor and must be owned by the user whose runtime
+// directory it is in, so a symlink swapped in cannot point the connection,
+// made as root, at anyone else's socket.
+func dialSocket(path string) (*os.File, error) {
+	uid, err := socketOwner(path)
+	if err != nil {
+		return nil, err
+	}
+	pinned, err := syscall.Open(path, oPath|syscall.O_NOFOLLOW|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: path, Err: err}
+	}
+	defer syscall.Close(pinned)
+	var st syscall.Stat_t
+	if err := syscall.Fstat(pinned, &st); err != nil {
+		return nil, &os.PathError{Op: "stat", Path: path, Err: err}
+	}
+	if st.Mode&syscall.S_IFMT != syscall.S_IFSOCK || int(st.Uid) != uid {
+		return nil, fmt.Errorf("%s is not a socket owned by uid %d", path, uid)
+	}
+
+	fd, err := syscall.Socket(syscall.AF_UNIX, syscall.SOCK_STREAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Connect(fd, &syscall.SockaddrUnix{Name: "/proc/self/fd/" + strconv.Itoa(pinned)}); err != nil {
+		syscall.Close(fd)
+		return nil, &os.PathError{Op: "connect", Path: path, Err: err}
+	}
+	return os.NewFile(uintptr(fd), path), nil
+}
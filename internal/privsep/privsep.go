@@ -0,0 +1,86 @@
+// Package privsep lets the daemon give up root once its devices and
+// sockets are open. Drop switches to an unprivileged user, keeping only the
+// capabilities asked for, and a small helper process that stays root opens
+// the device nodes the daemon needs later (hot-plugged Bluetooth keyboards,
+// hidraw nodes, /dev/uinput, LED brightness files) or connects to (a
+// user's compositor IPC socket) and passes the descriptors back over a
+// socketpair.
+package privsep
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"sync"
+)
+
+// Opener opens files on the daemon's behalf; the privilege helper's Client
+// and, with run --user, logind.Session implement it.
+type Opener interface {
+	Open(path string, flag int) (*os.File, error)
+}
+
+var (
+	mu     sync.RWMutex
+	helper Opener
+)
+
+// SetHelper routes OpenFile calls the daemon is not permitted to make
+// through o. Passing nil removes the helper.
+func SetHelper(o Opener) {
+	mu.Lock()
+	defer mu.Unlock()
+	helper = o
+}
+
+// OpenFile opens path like os.OpenFile. If that fails for lack of
+// permission and a helper is set, the helper opens it instead.
+func OpenFile(path string, flag int, perm os.FileMode) (*os.File, error) {
+	f, err := os.OpenFile(path, flag, perm)
+	if err == nil || !errors.Is(err, fs.ErrPermission) {
+		return f, err
+	}
+
+	mu.RLock()
+	c := helper
+	mu.RUnlock()
+	if c == nil {
+		return nil, err
+	}
+	return c.Open(path, flag)
+}
+
+// dialer is implemented by helpers that can connect to Unix sockets; the
+// privilege helper's Client does.
+type dialer interface {
+	Dial(path string) (*os.File, error)
+}
+
+// Dial connects to the Unix socket at path. If that fails for lack of
+// permission and the helper can connect to sockets, the helper connects
+// instead.
+func Dial(path string) (net.Conn, error) {
+	conn, err := net.Dial("unix", path)
+	if err == nil || !errors.Is(err, fs.ErrPermission) {
+		return conn, err
+	}
+
+	mu.RLock()
+	d, ok := helper.(dialer)
+	mu.RUnlock()
+	if !ok {
+		return nil, err
+	}
+	f, err := d.Dial(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	conn, err = net.FileConn(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,139 @@
+package privsep
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPath(t *testing.T) {
+	tests := []struct {
+		path string
+		flag int
+		want string
+	}{
+		{"/etc/shadow", os.O_RDONLY, "not on the helper's allow-list"},
+		{"/dev/input/../mem", os.O_RDONLY, "not clean"},
+		{"/dev/input/event0", os.O_RDWR | os.O_CREATE, "not allowed"},
+		{"/dev/input/event0", os.O_RDWR | os.O_TRUNC, "not allowed"},
+		{"/dev/input/mice", os.O_RDONLY, "not on the helper's allow-list"},
+		{"/dev/uinput", os.O_RDWR, "write-only"},
+		{"/sys/class/leds/x/trigger", os.O_WRONLY, "not on the helper's allow-list"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.ErrorContains(t, checkPath(tt.path, tt.flag), tt.want)
+		})
+	}
+}
+
+func TestParseCapabilities(t *testing.T) {
+	caps, err := ParseCapabilities([]string{"CAP_DAC_OVERRIDE", "net_bind_service"})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{1, 10}, caps)
+
+	_, err = ParseCapabilities([]string{"CAP_SETGID", "CAP_FLY"})
+	assert.ErrorContains(t, err, "CAP_SETGID would allow regaining root")
+	assert.ErrorContains(t, err, `unknown capability "CAP_FLY"`)
+}
+
+func TestClient_Open(t *testing.T) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_SEQPACKET|syscall.SOCK_CLOEXEC, 0)
+	require.NoError(t, err)
+	client := &Client{conn: os.NewFile(uintptr(fds[0]), "privsep")}
+	helperConn := os.NewFile(uintptr(fds[1]), "privsep-helper")
+
+	allowed := filepath.Join(t.TempDir(), "brightness")
+	require.NoError(t, os.WriteFile(allowed, []byte("1\n"), 0600))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- serve(helperConn, func(path string, flag int) error {
+			if path != allowed {
+				return checkPath(path, flag)
+			}
+			return nil
+		})
+		helperConn.Close()
+	}()
+
+	f, err := client.Open(allowed, os.O_RDONLY)
+	require.NoError(t, err)
+	data, err := io.ReadAll(f)
+	f.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "1\n", string(data))
+
+	_, err = client.Open("/etc/shadow", os.O_RDONLY)
+	assert.ErrorContains(t, err, "privilege helper: /etc/shadow is not on the helper's allow-list")
+
+	require.NoError(t, client.Stop())
+	assert.NoError(t, <-done, "the helper exits when the daemon goes away")
+}
+
+func TestClient_Dial(t *testing.T) {
+	old := userRuntimeDir
+	userRuntimeDir = t.TempDir()
+	defer func() { userRuntimeDir = old }()
+	uid := os.Getuid()
+	if uid == 0 {
+		uid = 1000 // root's own compositor is refused
+	}
+	dir := filepath.Join(userRuntimeDir, strconv.Itoa(uid))
+	require.NoError(t, os.Mkdir(dir, 0700))
+	listen := func(name string, owner int) (string, net.Listener) {
+		path := filepath.Join(dir, name)
+		l, err := net.Listen("unix", path)
+		require.NoError(t, err)
+		t.Cleanup(func() { l.Close() })
+		if owner != os.Getuid() {
+			require.NoError(t, os.Lchown(path, owner, -1))
+		}
+		return path, l
+	}
+	path, l := listen(fmt.Sprintf("sway-ipc.%d.42.sock", uid), uid)
+
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_SEQPACKET|syscall.SOCK_CLOEXEC, 0)
+	require.NoError(t, err)
+	client := &Client{conn: os.NewFile(uintptr(fds[0]), "privsep")}
+	helperConn := os.NewFile(uintptr(fds[1]), "privsep-helper")
+	done := make(chan error, 1)
+	go func() {
+		done <- serve(helperConn, checkPath)
+		helperConn.Close()
+	}()
+
+	f, err := client.Dial(path)
+	require.NoError(t, err)
+	f.Close()
+	accepted, err := l.Accept()
+	require.NoError(t, err)
+	accepted.Close()
+
+	link := filepath.Join(dir, fmt.Sprintf("sway-ipc.%d.43.sock", uid))
+	require.NoError(t, os.Symlink(path, link))
+	_, err = client.Dial(link)
+	assert.Error(t, err, "symlinks are not followed")
+
+	if os.Getuid() == 0 {
+		other, _ := listen(fmt.Sprintf("sway-ipc.%d.44.sock", uid), 0)
+		_, err = client.Dial(other)
+		assert.ErrorContains(t, err, "is not a socket owned by uid")
+	}
+
+	_, err = client.Dial(filepath.Join(userRuntimeDir, "0", "sway-ipc.0.1.sock"))
+	assert.ErrorContains(t, err, "not on the helper's allow-list")
+	_, err = client.Dial("/run/dbus/system_bus_socket")
+	assert.ErrorContains(t, err, "not on the helper's allow-list")
+
+	require.NoError(t, client.Stop())
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,188 @@
+// Package profile picks the palm rejection settings in effect. Profiles
+// are named sets of typing detection settings from the configuration; the
+// selector chooses one from a profile selected over the control socket,
+// the application in focus (reported by a FocusProvider) or the user
+// currently at the seat, and hands its settings to the typing detection
+// consumer.
+package profile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/consumer"
+)
+
+// Applier receives the settings of the selected profile.
+// consumer.TypingDetectionConsumer implements it.
+type Applier interface {
+	ApplySettings(consumer.Settings)
+}
+
+// FocusProvider reports which application has keyboard focus.
+type FocusProvider interface {
+	// SetOnFocus sets the callback run with the name of the focused
+	// application whenever focus moves; "" means it is unknown. Call
+	// before Start.
+	SetOnFocus(func(app string))
+	Start(ctx context.Context) error
+	Stop() error
+}
+
+// Sources of the selected profile, from highest precedence.
+const (
+	SourceManual  = "manual"
+	SourceApp     = "app"
+	SourceUser    = "user"
+	SourceDefault = "default"
+)
+
+// Status is a snapshot of the selection for the control interface.
+type Status struct {
+	Active string `json:"active,omitempty"`
+	Source string `json:"source"`
+	Manual string `json:"manual,omitempty"`
+	App    string `json:"app,omitempty"`
+	User   string `json:"user,omitempty"`
+}
+
+// Selector chooses the profile in effect.
+type Selector struct {
+	base     consumer.Settings
+	profiles map[string]consumer.Settings
+	users    map[string]string
+	apps     map[string]string
+	target   Applier
+	logger   zerolog.Logger
+
+	mu     sync.Mutex
+	manual string
+	app    string
+	user   string
+	active string
+	source string
+}
+
+// NewSelector creates a selector. base applies when no profile is
+// selected; users maps user names to profile names.
+func NewSelector(base consumer.Settings, profiles map[string]consumer.Settings, users map[string]string, target Applier, logger zerolog.Logger) *Selector {
+	return &Selector{
+		base:     base,
+		profiles: profiles,
+		users:    users,
+		target:   target,
+		logger:   logger.With().Str("component", "profiles").Logger(),
+		source:   SourceDefault,
+	}
+}
+
+// SetApps sets the profile names by application. Application names are
+// matched case-insensitively. Call before the selector is used.
+func (s *Selector) SetApps(apps map[string]string) {
+	s.apps = make(map[string]string, len(apps))
+	for app, name := range apps {
+		s.apps[strings.ToLower(app)] = name
+	}
+}
+
+// Names returns the configured profile names in order.
+func (s *Selector) Names() []string {
+	names := make([]string, 0, len(s.profiles))
+	for name := range s.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetUser selects the profile of the user now in front of the machine.
+func (s *Selector) SetUser(user string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.user = user
+	s.applyLocked()
+}
+
+// SetApp selects the profile of the application that now has focus.
+func (s *Selector) SetApp(app string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.app = app
+	s.applyLocked()
+}
+
+// Select forces the named profile regardless of application and user; an
+// empty name returns to automatic selection.
+func (s *Selector) Select(name string) error {
+	if _, ok := s.profiles[name]; name != "" && !ok {
+		return fmt.Errorf("unknown profile %q (have: %s)", name, strings.Join(s.Names(), ", "))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.manual = name
+	s.applyLocked()
+	return nil
+}
+
+// Status returns the selected profile and the inputs it was chosen from.
+func (s *Selector) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Status{Active: s.active, Source: s.source, Manual: s.manual, App: s.app, User: s.user}
+}
+
+// String describes the selection in one line, e.g. "drawing (app: krita)".
+func (s *Selector) String() string {
+	st := s.Status()
+	name := st.Active
+	if name == "" {
+		name = "default"
+	}
+	switch st.Source {
+	case SourceApp:
+		return fmt.Sprintf("%s (app: %s)", name, st.App)
+	case SourceUser:
+		return fmt.Sprintf("%s (user: %s)", name, st.User)
+	case SourceManual:
+		return name + " (manual)"
+	}
+	return name
+}
+
+// chooseLocked returns the profile for the current inputs: a manual selection
+// wins over the focused application, which wins over the user. Callers
+// must hold s.mu.
+func (s *Selector) chooseLocked() (string, string) {
+	if s.manual != "" {
+		return s.manual, SourceManual
+	}
+	if name, ok := s.apps[strings.ToLower(s.app)]; ok && s.app != "" {
+		return name, SourceApp
+	}
+	if name, ok := s.users[s.user]; ok {
+		return name, SourceUser
+	}
+	return "", SourceDefault
+}
+
+// applyLocked applies the profile chosen by the current inputs if it
+// differs from the one in effect. Callers must hold s.mu.
+func (s *Selector) applyLocked() {
+	name, source := s.chooseLocked()
+	settings, ok := s.profiles[name]
+	if !ok {
+		name, source, settings = "", SourceDefault, s.base
+	}
+	s.source = source
+	if name == s.active {
+		return
+	}
+	s.active = name
+	s.logger.Info().Str("profile", name).Str("source", source).Str("app", s.app).Str("user", s.user).Msg("Profile selected")
+	s.target.ApplySettings(settings)
+}
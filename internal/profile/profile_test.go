@@ -0,0 +1,64 @@
+package profile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/consumer"
+)
+
+type fakeApplier struct {
+	applied []consumer.Settings
+}
+
+func (f *fakeApplier) ApplySettings(s consumer.Settings) { f.applied = append(f.applied, s) }
+
+func TestSelector_SetUser(t *testing.T) {
+	base := consumer.Settings{Cooldown: 300 * time.Millisecond}
+	drawing := consumer.Settings{Cooldown: time.Second}
+	target := &fakeApplier{}
+	s := NewSelector(base, map[string]consumer.Settings{"drawing": drawing}, map[string]string{"alice": "drawing"}, target, zerolog.Nop())
+
+	assert.Equal(t, []string{"drawing"}, s.Names())
+
+	s.SetUser("alice")
+	assert.Equal(t, Status{Active: "drawing", Source: SourceUser, User: "alice"}, s.Status())
+
+	s.SetUser("bob")
+	assert.Equal(t, Status{Source: SourceDefault, User: "bob"}, s.Status(), "users without a profile get the base settings")
+	assert.Equal(t, []consumer.Settings{drawing, base}, target.applied)
+}
+
+func TestSelector_Precedence(t *testing.T) {
+	profiles := map[string]consumer.Settings{
+		"editor": {Cooldown: time.Second},
+		"gaming": {Off: true},
+		"slow":   {Cooldown: 2 * time.Second},
+	}
+	target := &fakeApplier{}
+	s := NewSelector(consumer.Settings{Cooldown: 300 * time.Millisecond}, profiles, map[string]string{"alice": "slow"}, target, zerolog.Nop())
+	s.SetApps(map[string]string{"Code": "editor", "steam_app_730": "gaming"})
+
+	s.SetUser("alice")
+	assert.Equal(t, "slow (user: alice)", s.String())
+
+	s.SetApp("code")
+	assert.Equal(t, "editor (app: code)", s.String(), "apps match case-insensitively and win over the user")
+
+	require.NoError(t, s.Select("gaming"))
+	assert.Equal(t, "gaming (manual)", s.String())
+	s.SetApp("firefox")
+	assert.Equal(t, "gaming (manual)", s.String(), "a manual selection wins over focus")
+
+	assert.ErrorContains(t, s.Select("drawing"), `unknown profile "drawing" (have: editor, gaming, slow)`)
+	require.NoError(t, s.Select(""))
+	assert.Equal(t, "slow (user: alice)", s.String())
+
+	// Focus moving between apps without a profile applies nothing new
+	s.SetApp("foot")
+	assert.Equal(t, []consumer.Settings{profiles["slow"], profiles["editor"], profiles["gaming"], profiles["slow"]}, target.applied)
+}
@@ -0,0 +1,361 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/privsep"
+)
+
+// runtimeDirs holds the users' runtime directories and procDir the procfs
+// mount point; tests point them at temporary directories.
+var (
+	runtimeDirs = "/run/user"
+	procDir     = "/proc"
+)
+
+// maxIPCMessage bounds the payload of an IPC message; a tree of even a
+// crowded desktop is far smaller.
+const maxIPCMessage = 16 << 20
+
+// DefaultReconnectInterval is how long Sway waits before reconnecting
+// after the compositor went away or was not running yet.
+const DefaultReconnectInterval = 5 * time.Second
+
+// i3 IPC message and event types; sway speaks the same protocol.
+const (
+	ipcMagic     = "i3-ipc"
+	ipcSubscribe = 2
+	ipcGetTree   = 4
+	ipcEventBit  = 1 << 31
+	ipcWindow    = ipcEventBit | 3
+)
+
+// Sway follows window focus over the sway/i3 IPC socket.
+type Sway struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	socket   string
+	interval time.Duration
+	logger   zerolog.Logger
+	onFocus  func(app string)
+	done     chan struct{}
+
+	mu   sync.Mutex
+	conn net.Conn
+	app  string
+	// uid is the user whose compositor is followed, -1 if unknown
+	uid int
+}
+
+// NewSway creates a focus provider for the IPC socket at socket. An empty
+// path is looked up on every connection attempt: SWAYSOCK, I3SOCK, and
+// otherwise the socket of a sway or i3 process of the user set with
+// SetUID, by default the daemon's own unless it runs as root.
+func NewSway(socket string, logger zerolog.Logger) *Sway {
+	uid := os.Getuid()
+	if uid == 0 {
+		uid = -1
+	}
+	return &Sway{
+		socket:   socket,
+		interval: DefaultReconnectInterval,
+		uid:      uid,
+		logger:   logger.With().Str("component", "focus").Logger(),
+	}
+}
+
+// SetUID selects the user whose compositor is followed, e.g. that of the
+// seat's active session; -1 follows none. A change reconnects.
+func (s *Sway) SetUID(uid int) {
+	s.mu.Lock()
+	changed := uid != s.uid
+	s.uid = uid
+	conn := s.conn
+	s.mu.Unlock()
+	if changed && conn != nil && s.socket == "" {
+		conn.Close() // follow reconnects to the new user's socket
+	}
+}
+
+// SetOnFocus implements FocusProvider.
+func (s *Sway) SetOnFocus(fn func(app string)) {
+	s.onFocus = fn
+}
+
+// Start connects in the background and keeps reconnecting until Stop.
+func (s *Sway) Start(ctx context.Context) error {
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+	go s.run()
+	return nil
+}
+
+// Stop disconnects from the compositor.
+func (s *Sway) Stop() error {
+	if s.cancel != nil {
+		s.cancel()
+		s.mu.Lock()
+		if s.conn != nil {
+			s.conn.Close()
+		}
+		s.mu.Unlock()
+		<-s.done
+	}
+	return nil
+}
+
+// App returns the focused application, or "" while not connected.
+func (s *Sway) App() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.app
+}
+
+func (s *Sway) run() {
+	defer close(s.done)
+	for {
+		path, err := s.socketPath()
+		if err == nil {
+			err = s.follow(path)
+		}
+		if s.ctx.Err() != nil {
+			return
+		}
+		// Focus is unknown until the compositor is back
+		s.setApp("")
+		s.logger.Debug().Err(err).Dur("retry_in", s.interval).Msg("not connected to the compositor")
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(s.interval):
+		}
+	}
+}
+
+// follow reports focus changes until the connection ends.
+func (s *Sway) follow(path string) error {
+	// The user's runtime directory is private; after dropping root the
+	// privilege helper connects
+	conn, err := privsep.Dial(path)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.conn = nil
+		s.mu.Unlock()
+		conn.Close()
+	}()
+	if s.ctx.Err() != nil {
+		return s.ctx.Err()
+	}
+
+	if err := writeMessage(conn, ipcGetTree, nil); err != nil {
+		return err
+	}
+	_, tree, err := readMessage(conn)
+	if err != nil {
+		return err
+	}
+	var root node
+	if err := json.Unmarshal(tree, &root); err != nil {
+		return fmt.Errorf("invalid tree: %w", err)
+	}
+	if focused := root.focused(); focused != nil {
+		s.setApp(focused.appName())
+	}
+	s.logger.Info().Str("socket", path).Str("app", s.App()).Msg("Following window focus")
+
+	if err := writeMessage(conn, ipcSubscribe, []byte(`["window"]`)); err != nil {
+		return err
+	}
+	for {
+		kind, payload, err := readMessage(conn)
+		if err != nil {
+			return err
+		}
+		if kind != ipcWindow {
+			continue // the reply to the subscription
+		}
+		var event struct {
+			Change    string `json:"change"`
+			Container node   `json:"container"`
+		}
+		if json.Unmarshal(payload, &event) != nil || event.Change != "focus" {
+			continue
+		}
+		s.setApp(event.Container.appName())
+	}
+}
+
+func (s *Sway) setApp(app string) {
+	s.mu.Lock()
+	changed := app != s.app
+	s.app = app
+	s.mu.Unlock()
+	if !changed {
+		return
+	}
+	s.logger.Debug().Str("app", app).Msg("Focus changed")
+	if s.onFocus != nil {
+		s.onFocus(app)
+	}
+}
+
+func (s *Sway) socketPath() (string, error) {
+	if s.socket != "" {
+		return s.socket, nil
+	}
+	for _, env := range []string{"SWAYSOCK", "I3SOCK"} {
+		if path := os.Getenv(env); path != "" {
+			return path, nil
+		}
+	}
+	// A root daemon has neither variable; find the compositor of the
+	// user at the seat. Their runtime directory cannot be listed once root
+	// is dropped, but the socket names follow from the compositor's pid.
+	s.mu.Lock()
+	uid := s.uid
+	s.mu.Unlock()
+	if uid < 0 {
+		return "", errors.New("no user to follow the compositor of")
+	}
+	dir := filepath.Join(runtimeDirs, strconv.Itoa(uid))
+	for _, p := range userProcesses(uid) {
+		var path string
+		switch p.comm {
+		case "sway":
+			path = filepath.Join(dir, fmt.Sprintf("sway-ipc.%d.%d.sock", uid, p.pid))
+		case "i3":
+			path = filepath.Join(dir, "i3", fmt.Sprintf("ipc-socket.%d", p.pid))
+		default:
+			continue
+		}
+		if _, err := os.Lstat(path); err == nil || errors.Is(err, os.ErrPermission) {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no sway or i3 IPC socket found for uid %d", uid)
+}
+
+type process struct {
+	pid  int
+	comm string
+}
+
+// userProcesses lists the processes of uid, newest (highest pid) first.
+func userProcesses(uid int) []process {
+	entries, err := os.ReadDir(procDir)
+	if err != nil {
+		return nil
+	}
+	var procs []process
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(procDir, e.Name()))
+		if err != nil {
+			continue
+		}
+		if st, ok := info.Sys().(*syscall.Stat_t); !ok || int(st.Uid) != uid {
+			continue
+		}
+		comm, err := os.ReadFile(filepath.Join(procDir, e.Name(), "comm"))
+		if err != nil {
+			continue
+		}
+		procs = append(procs, process{pid: pid, comm: strings.TrimSpace(string(comm))})
+	}
+	sort.Slice(procs, func(i, j int) bool { return procs[i].pid > procs[j].pid })
+	return procs
+}
+
+// node is the part of a sway/i3 tree node that matters here.
+type node struct {
+	Focused          bool   `json:"focused"`
+	AppID            string `json:"app_id"`
+	WindowProperties struct {
+		Class string `json:"class"`
+	} `json:"window_properties"`
+	Nodes         []node `json:"nodes"`
+	FloatingNodes []node `json:"floating_nodes"`
+}
+
+// appName is the Wayland app_id, or the X11 window class for Xwayland and
+// i3 windows.
+func (n *node) appName() string {
+	if n.AppID != "" {
+		return n.AppID
+	}
+	return n.WindowProperties.Class
+}
+
+// focused returns the focused node in the tree below n.
+func (n *node) focused() *node {
+	if n.Focused {
+		return n
+	}
+	for _, children := range [][]node{n.Nodes, n.FloatingNodes} {
+		for i := range children {
+			if f := children[i].focused(); f != nil {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// writeMessage sends an IPC message: the magic string, the payload length
+// and the message type in native byte order, then the payload.
+func writeMessage(w io.Writer, kind uint32, payload []byte) error {
+	var buf bytes.Buffer
+	buf.WriteString(ipcMagic)
+	binary.Write(&buf, binary.NativeEndian, uint32(len(payload)))
+	binary.Write(&buf, binary.NativeEndian, kind)
+	buf.Write(payload)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// readMessage reads one IPC reply or event.
+func readMessage(r io.Reader) (uint32, []byte, error) {
+	header := make([]byte, len(ipcMagic)+8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+	if string(header[:len(ipcMagic)]) != ipcMagic {
+		return 0, nil, errors.New("invalid IPC message")
+	}
+	length := binary.NativeEndian.Uint32(header[len(ipcMagic):])
+	kind := binary.NativeEndian.Uint32(header[len(ipcMagic)+4:])
+	if length > maxIPCMessage {
+		return 0, nil, fmt.Errorf("IPC message of %d bytes is too large", length)
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	return kind, payload, nil
+}
@@ -0,0 +1,129 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTree = `{"nodes": [{"nodes": [
+	{"app_id": "foot", "nodes": []},
+	{"app_id": null, "window_properties": {"class": "Steam"}, "floating_nodes": [
+		{"focused": true, "app_id": null, "window_properties": {"class": "steam_app_730"}}
+	]}
+]}]}`
+
+// serveIPC answers one client like sway would: the tree, the subscription,
+// then the given window events.
+func serveIPC(t *testing.T, l net.Listener, events ...string) {
+	t.Helper()
+	conn, err := l.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	kind, _, err := readMessage(conn)
+	require.NoError(t, err)
+	require.Equal(t, uint32(ipcGetTree), kind)
+	require.NoError(t, writeMessage(conn, ipcGetTree, []byte(testTree)))
+
+	kind, payload, err := readMessage(conn)
+	require.NoError(t, err)
+	require.Equal(t, uint32(ipcSubscribe), kind)
+	assert.JSONEq(t, `["window"]`, string(payload))
+	require.NoError(t, writeMessage(conn, ipcSubscribe, []byte(`{"success": true}`)))
+
+	for _, event := range events {
+		require.NoError(t, writeMessage(conn, ipcWindow, []byte(event)))
+	}
+}
+
+func TestSway_Focus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sway-ipc.sock")
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer l.Close()
+
+	focus := make(chan string, 8)
+	s := NewSway(path, zerolog.Nop())
+	s.interval = time.Hour
+	s.SetOnFocus(func(app string) { focus <- app })
+	require.NoError(t, s.Start(context.Background()))
+	defer s.Stop()
+
+	serveIPC(t, l,
+		`{"change": "title", "container": {"app_id": "foot"}}`,
+		`{"change": "focus", "container": {"app_id": "org.kde.krita"}}`,
+	)
+
+	var got []string
+	for len(got) < 3 {
+		select {
+		case app := <-focus:
+			got = append(got, app)
+		case <-time.After(time.Second):
+			t.Fatalf("focus changes so far: %v", got)
+		}
+	}
+	assert.Equal(t, []string{"steam_app_730", "org.kde.krita", ""}, got, "focus is unknown once the compositor goes away")
+}
+
+func TestSway_SocketPath(t *testing.T) {
+	runtimeDirs, procDir = t.TempDir(), t.TempDir()
+	defer func() { runtimeDirs, procDir = "/run/user", "/proc" }()
+	t.Setenv("SWAYSOCK", "")
+	t.Setenv("I3SOCK", "")
+	uid := os.Getuid()
+	process := func(pid int, comm string) {
+		dir := filepath.Join(procDir, strconv.Itoa(pid))
+		require.NoError(t, os.Mkdir(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "comm"), []byte(comm+"\n"), 0644))
+	}
+	process(41, "foot")
+	process(42, "sway")
+
+	s := NewSway("", zerolog.Nop())
+	s.SetUID(-1)
+	_, err := s.socketPath()
+	assert.ErrorContains(t, err, "no user to follow")
+
+	s.SetUID(uid)
+	_, err = s.socketPath()
+	assert.ErrorContains(t, err, "no sway or i3 IPC socket found", "sway is running but has no socket yet")
+
+	path := filepath.Join(runtimeDirs, strconv.Itoa(uid), fmt.Sprintf("sway-ipc.%d.42.sock", uid))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer l.Close()
+	got, err := s.socketPath()
+	require.NoError(t, err)
+	assert.Equal(t, path, got)
+
+	s.SetUID(uid + 1)
+	_, err = s.socketPath()
+	assert.Error(t, err, "only the compositor of the given user")
+
+	t.Setenv("I3SOCK", "/tmp/i3.sock")
+	got, _ = s.socketPath()
+	assert.Equal(t, "/tmp/i3.sock", got)
+}
+
+func TestReadMessage_TooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString(ipcMagic)
+	binary.Write(&buf, binary.NativeEndian, uint32(1<<31))
+	binary.Write(&buf, binary.NativeEndian, uint32(ipcWindow))
+	_, _, err := readMessage(&buf)
+	assert.ErrorContains(t, err, "too large")
+}
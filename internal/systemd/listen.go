@@ -0,0 +1,68 @@
+package systemd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// listenFDsStart is the first file descriptor passed by systemd
+// (SD_LISTEN_FDS_START); replaced in tests.
+var listenFDsStart = 3
+
+// Listeners returns the sockets passed by systemd socket activation, keyed
+// by their FileDescriptorName= (the socket unit's name by default). It
+// returns an empty map when the daemon was not socket activated. Of several
+// sockets sharing a name only the first is kept. The LISTEN_* variables are
+// cleared so child processes do not pick them up.
+func Listeners() (map[string]net.Listener, error) {
+	defer func() {
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_FDNAMES")
+	}()
+
+	listeners := make(map[string]net.Listener)
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return listeners, nil
+	}
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count <= 0 {
+		return listeners, nil
+	}
+
+	var names []string
+	if s := os.Getenv("LISTEN_FDNAMES"); s != "" {
+		names = strings.Split(s, ":")
+	}
+
+	for i := 0; i < count; i++ {
+		fd := listenFDsStart + i
+		syscall.CloseOnExec(fd)
+
+		name := "unknown"
+		if i < len(names) {
+			name = names[i]
+		}
+		f := os.NewFile(uintptr(fd), name)
+		ln, err := net.FileListener(f)
+		// FileListener dups the descriptor
+		f.Close()
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return nil, fmt.Errorf("passed fd %d (%s) is not a listening socket: %w", fd, name, err)
+		}
+		if _, ok := listeners[name]; ok {
+			ln.Close()
+			continue
+		}
+		listeners[name] = ln
+	}
+	return listeners, nil
+}
@@ -0,0 +1,50 @@
+// Package systemd implements the parts of the systemd service protocol the
+// daemon uses: readiness and status notification (sd_notify), watchdog
+// keep-alives and socket activation (LISTEN_FDS). It has no dependency on
+// libsystemd; everything is driven by the environment systemd sets up and
+// is a no-op when the daemon is not started by systemd.
+package systemd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+// Notification states understood by systemd.
+const (
+	StateReady    = "READY=1"
+	StateStopping = "STOPPING=1"
+	StateWatchdog = "WATCHDOG=1"
+)
+
+// Status returns a STATUS= notification, shown by "systemctl status".
+func Status(format string, args ...any) string {
+	return "STATUS=" + strings.ReplaceAll(fmt.Sprintf(format, args...), "\n", " ")
+}
+
+// Notify sends states to the service manager in one datagram. It does
+// nothing when NOTIFY_SOCKET is unset, i.e. when not run by systemd or
+// when the unit is not Type=notify.
+func Notify(states ...string) error {
+	path := os.Getenv("NOTIFY_SOCKET")
+	if path == "" {
+		return nil
+	}
+	if path[0] == '@' {
+		// Abstract namespace socket
+		path = "\x00" + path[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return fmt.Errorf("failed to connect to notify socket: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(strings.Join(states, "\n"))); err != nil {
+		return fmt.Errorf("failed to notify systemd: %w", err)
+	}
+	return nil
+}
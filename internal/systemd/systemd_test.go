@@ -0,0 +1,136 @@
+package systemd
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	t.Setenv("NOTIFY_SOCKET", path)
+	require.NoError(t, Notify(StateReady, Status("Palm rejection active:\n2 touchpads")))
+
+	buf := make([]byte, 256)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "READY=1\nSTATUS=Palm rejection active: 2 touchpads", string(buf[:n]))
+}
+
+func TestNotify_NotUnderSystemd(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	assert.NoError(t, Notify(StateReady))
+}
+
+func TestWatchdogInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		usec    string
+		pid     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "disabled", usec: "", want: 0},
+		{name: "enabled", usec: "30000000", want: 30 * time.Second},
+		{name: "this process", usec: "30000000", pid: strconv.Itoa(os.Getpid()), want: 30 * time.Second},
+		{name: "other process", usec: "30000000", pid: "1", want: 0},
+		{name: "invalid", usec: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("WATCHDOG_USEC", tt.usec)
+			t.Setenv("WATCHDOG_PID", tt.pid)
+			got, err := WatchdogInterval()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWatchdog_WithholdsPingsWhenUnhealthy(t *testing.T) {
+	var mu sync.Mutex
+	var sent []string
+	var healthErr error
+
+	w := NewWatchdog(time.Second, []HealthCheck{{
+		Name: "kb_monitor",
+		Check: func() error {
+			mu.Lock()
+			defer mu.Unlock()
+			return healthErr
+		},
+	}}, zerolog.Nop())
+	w.notify = func(states ...string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, states...)
+		return nil
+	}
+
+	w.tick()
+	assert.True(t, w.Status().Healthy)
+
+	mu.Lock()
+	healthErr = errors.New("read loop stopped")
+	mu.Unlock()
+	w.tick()
+	w.tick()
+	status := w.Status()
+	assert.False(t, status.Healthy)
+	assert.Equal(t, "kb_monitor: read loop stopped", status.Error)
+
+	assert.Equal(t, []string{StateWatchdog, "STATUS=Unhealthy: kb_monitor: read loop stopped"}, sent)
+}
+
+func TestListeners(t *testing.T) {
+	ln, err := net.Listen("unix", filepath.Join(t.TempDir(), "control.sock"))
+	require.NoError(t, err)
+	defer ln.Close()
+	// Listeners takes ownership of the duplicated descriptor, like fd 3 under systemd
+	f, err := ln.(*net.UnixListener).File()
+	require.NoError(t, err)
+
+	old := listenFDsStart
+	listenFDsStart = int(f.Fd())
+	defer func() { listenFDsStart = old }()
+
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+	t.Setenv("LISTEN_FDS", "1")
+	t.Setenv("LISTEN_FDNAMES", "palm-reject-daemon.socket")
+
+	listeners, err := Listeners()
+	require.NoError(t, err)
+	require.Contains(t, listeners, "palm-reject-daemon.socket")
+	defer listeners["palm-reject-daemon.socket"].Close()
+	assert.Equal(t, ln.Addr().String(), listeners["palm-reject-daemon.socket"].Addr().String())
+
+	_, set := os.LookupEnv("LISTEN_FDS")
+	assert.False(t, set, "LISTEN_FDS must be cleared")
+}
+
+func TestListeners_NotActivated(t *testing.T) {
+	t.Setenv("LISTEN_PID", "1")
+	t.Setenv("LISTEN_FDS", "1")
+
+	listeners, err := Listeners()
+	require.NoError(t, err)
+	assert.Empty(t, listeners)
+}
@@ -0,0 +1,157 @@
+package systemd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// WatchdogInterval returns the WatchdogSec= of the unit, or 0 when the
+// watchdog is not enabled for this process.
+func WatchdogInterval() (time.Duration, error) {
+	s := os.Getenv("WATCHDOG_USEC")
+	if s == "" {
+		return 0, nil
+	}
+	usec, err := strconv.ParseUint(s, 10, 63)
+	if err != nil || usec == 0 {
+		return 0, fmt.Errorf("invalid WATCHDOG_USEC %q", s)
+	}
+	if p := os.Getenv("WATCHDOG_PID"); p != "" {
+		pid, err := strconv.Atoi(p)
+		if err != nil {
+			return 0, fmt.Errorf("invalid WATCHDOG_PID %q", p)
+		}
+		if pid != os.Getpid() {
+			return 0, nil
+		}
+	}
+	return time.Duration(usec) * time.Microsecond, nil
+}
+
+// HealthCheck reports whether one part of the daemon is still working.
+type HealthCheck struct {
+	Name  string
+	Check func() error
+}
+
+// WatchdogStatus is reported by the control interface.
+type WatchdogStatus struct {
+	Interval string `json:"interval"`
+	Healthy  bool   `json:"healthy"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Watchdog sends WATCHDOG=1 at half the watchdog interval as long as every
+// health check passes. When one fails the pings stop and systemd restarts
+// the service once the interval has passed, so a wedged component does not
+// leave the daemon running without palm rejection.
+type Watchdog struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	interval time.Duration
+	checks   []HealthCheck
+	notify   func(states ...string) error
+	logger   zerolog.Logger
+	done     chan struct{}
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+// NewWatchdog creates a watchdog for the given interval (see WatchdogInterval).
+func NewWatchdog(interval time.Duration, checks []HealthCheck, logger zerolog.Logger) *Watchdog {
+	return &Watchdog{
+		interval: interval,
+		checks:   checks,
+		notify:   Notify,
+		logger:   logger.With().Str("component", "watchdog").Logger(),
+	}
+}
+
+// Start begins pinging systemd.
+func (w *Watchdog) Start(ctx context.Context) error {
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+	go w.loop()
+	w.logger.Info().Dur("interval", w.interval).Msg("Watchdog started")
+	return nil
+}
+
+// Stop stops pinging.
+func (w *Watchdog) Stop() error {
+	if w.cancel != nil {
+		w.cancel()
+		<-w.done
+	}
+	w.logger.Info().Msg("Watchdog stopped")
+	return nil
+}
+
+// Status returns the result of the last health check.
+func (w *Watchdog) Status() WatchdogStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	status := WatchdogStatus{Interval: w.interval.String(), Healthy: w.lastErr == nil}
+	if w.lastErr != nil {
+		status.Error = w.lastErr.Error()
+	}
+	return status
+}
+
+func (w *Watchdog) loop() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval / 2)
+	defer ticker.Stop()
+	w.tick()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			w.tick()
+		}
+	}
+}
+
+// tick runs the health checks and pings systemd if they all pass.
+func (w *Watchdog) tick() {
+	err := w.check()
+
+	w.mu.Lock()
+	wasHealthy := w.lastErr == nil
+	w.lastErr = err
+	w.mu.Unlock()
+
+	if err != nil {
+		if wasHealthy {
+			w.logger.Error().Err(err).Msg("Health check failed; withholding watchdog pings")
+			if nerr := w.notify(Status("Unhealthy: %v", err)); nerr != nil {
+				w.logger.Warn().Err(nerr).Msg("Failed to notify systemd")
+			}
+		}
+		return
+	}
+	if !wasHealthy {
+		w.logger.Info().Msg("Health checks pass again")
+	}
+	if nerr := w.notify(StateWatchdog); nerr != nil {
+		w.logger.Warn().Err(nerr).Msg("Failed to notify systemd")
+	}
+}
+
+func (w *Watchdog) check() error {
+	var errs []error
+	for _, c := range w.checks {
+		if err := c.Check(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,260 @@
+package touchpad
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/privacy"
+)
+
+// DefaultBluetoothPollInterval is how often BluetoothManager rescans the
+// input devices.
+const DefaultBluetoothPollInterval = time.Second
+
+// keyboardSource is a started keyboard monitor; KeyboardMonitor implements it.
+type keyboardSource interface {
+	Start(ctx context.Context) error
+	Stop() error
+}
+
+// touchpadSet is the set of controlled touchpads; MultiController implements it.
+type touchpadSet interface {
+	AddDevice(dev *DeviceInfo) error
+	RemoveDevice(path string)
+	Has(path string) bool
+}
+
+// BluetoothPair is a Bluetooth keyboard and the touchpads on the same HID device.
+type BluetoothPair struct {
+	Keyboard  string   `json:"keyboard"`
+	Name      string   `json:"name"`
+	Touchpads []string `json:"touchpads"`
+}
+
+// tracked is a hot-plugged node and the HID device it belonged to when it
+// was picked up. A reconnect may reuse the event node under a new HID
+// device, which must be treated as a new device.
+type tracked struct {
+	parent  string
+	name    string
+	monitor keyboardSource
+}
+
+// BluetoothManager follows Bluetooth keyboards as they connect and
+// disconnect: each keyboard gets its own monitor feeding onKey, and
+// the touchpad on the same HID device is added to the touchpad set while
+// the keyboard is connected. No restart is needed after a reconnect.
+type BluetoothManager struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	touchpads touchpadSet
+	onKey     func(KeyEvent)
+	onChange  func()
+	interval  time.Duration
+	keys      privacy.KeyPolicy
+	filter    KeyFilter
+	chord     []evdev.EvCode
+	onChord   func()
+	logger    zerolog.Logger
+	done      chan struct{}
+	// newKeyboard creates the monitor for a keyboard; replaced in tests
+	newKeyboard func(path string) keyboardSource
+
+	mu        sync.Mutex
+	keyboards map[string]*tracked
+	pads      map[string]*tracked
+}
+
+// NewBluetoothManager creates a manager adding touchpads to touchpads and
+// reporting the key events of each keyboard to onKey. onChange, if set, is
+// called after the set of connected devices changed.
+func NewBluetoothManager(touchpads touchpadSet, onKey func(KeyEvent), onChange func(), logger zerolog.Logger) *BluetoothManager {
+	m := &BluetoothManager{
+		touchpads: touchpads,
+		onKey:     onKey,
+		onChange:  onChange,
+		interval:  DefaultBluetoothPollInterval,
+		logger:    logger.With().Str("component", "bluetooth").Logger(),
+		keyboards: make(map[string]*tracked),
+		pads:      make(map[string]*tracked),
+	}
+	m.newKeyboard = func(path string) keyboardSource {
+		monitor := NewKeyboardMonitor(path, m.onKey, logger)
+		monitor.SetKeyPolicy(m.keys)
+		monitor.SetKeyFilter(m.filter)
+		monitor.SetChord(m.chord, m.onChord)
+		return monitor
+	}
+	return m
+}
+
+// SetKeyPolicy sets what the keyboard monitors log about key presses.
+// Call before Start.
+func (m *BluetoothManager) SetKeyPolicy(p privacy.KeyPolicy) {
+	m.keys = p
+}
+
+// SetKeyFilter sets which key presses the keyboard monitors ignore. Call
+// before Start.
+func (m *BluetoothManager) SetKeyFilter(f KeyFilter) {
+	m.filter = f
+}
+
+// SetChord sets the hotkey chord of the keyboard monitors. Call before
+// Start.
+func (m *BluetoothManager) SetChord(codes []evdev.EvCode, fn func()) {
+	m.chord = codes
+	m.onChord = fn
+}
+
+// Start picks up the connected keyboards and starts polling for changes.
+func (m *BluetoothManager) Start(ctx context.Context) error {
+	m.ctx, m.cancel = context.WithCancel(ctx)
+	m.done = make(chan struct{})
+	m.poll()
+	go m.pollLoop()
+	m.logger.Info().Msg("Bluetooth manager started")
+	return nil
+}
+
+// Stop stops polling and all keyboard monitors. Touchpads stay in the set;
+// they are closed with it.
+func (m *BluetoothManager) Stop() error {
+	if m.cancel != nil {
+		m.cancel()
+		<-m.done
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for path, kb := range m.keyboards {
+		kb.monitor.Stop()
+		delete(m.keyboards, path)
+	}
+	m.logger.Info().Msg("Bluetooth manager stopped")
+	return nil
+}
+
+// Pairs returns the connected keyboards and their touchpads.
+func (m *BluetoothManager) Pairs() []BluetoothPair {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pairs := []BluetoothPair{}
+	for path, kb := range m.keyboards {
+		pair := BluetoothPair{Keyboard: path, Name: kb.name, Touchpads: []string{}}
+		for padPath, pad := range m.pads {
+			if pad.parent == kb.parent {
+				pair.Touchpads = append(pair.Touchpads, padPath)
+			}
+		}
+		sort.Strings(pair.Touchpads)
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Keyboard < pairs[j].Keyboard })
+	return pairs
+}
+
+func (m *BluetoothManager) pollLoop() {
+	defer close(m.done)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.poll()
+		}
+	}
+}
+
+// poll reconciles the tracked devices with the devices present in sysfs.
+func (m *BluetoothManager) poll() {
+	devices, err := ScanInputDevices()
+	if err != nil {
+		m.logger.Warn().Err(err).Msg("Failed to scan input devices")
+		return
+	}
+
+	keyboards := make(map[string]*InputDevice)
+	parents := make(map[string]bool)
+	for _, d := range devices {
+		if d.Class == ClassKeyboard && d.ID.BusType == uint16(evdev.BUS_BLUETOOTH) && d.HIDParent != "" {
+			keyboards[d.Path] = d
+			parents[d.HIDParent] = true
+		}
+	}
+	pads := make(map[string]*InputDevice)
+	for _, d := range devices {
+		if d.Class == ClassTouchpad && parents[d.HIDParent] {
+			pads[d.Path] = d
+		}
+	}
+
+	m.mu.Lock()
+	changed := m.reconcileKeyboards(keyboards)
+	changed = m.reconcileTouchpads(pads) || changed
+	m.mu.Unlock()
+
+	if changed && m.onChange != nil {
+		m.onChange()
+	}
+}
+
+func (m *BluetoothManager) reconcileKeyboards(present map[string]*InputDevice) bool {
+	changed := false
+	for path, kb := range m.keyboards {
+		if d, ok := present[path]; !ok || d.HIDParent != kb.parent {
+			kb.monitor.Stop()
+			delete(m.keyboards, path)
+			m.logger.Info().Str("device", path).Str("name", kb.name).Msg("Bluetooth keyboard disconnected")
+			changed = true
+		}
+	}
+	for path, d := range present {
+		if _, ok := m.keyboards[path]; ok {
+			continue
+		}
+		monitor := m.newKeyboard(path)
+		if err := monitor.Start(m.ctx); err != nil {
+			// Freshly connected devices can take a moment to become
+			// accessible; try again on the next poll.
+			m.logger.Warn().Err(err).Str("device", path).Msg("Failed to monitor Bluetooth keyboard")
+			continue
+		}
+		m.keyboards[path] = &tracked{parent: d.HIDParent, name: d.Name, monitor: monitor}
+		m.logger.Info().Str("device", path).Str("name", d.Name).Msg("Bluetooth keyboard connected")
+		changed = true
+	}
+	return changed
+}
+
+func (m *BluetoothManager) reconcileTouchpads(present map[string]*InputDevice) bool {
+	changed := false
+	for path, pad := range m.pads {
+		if d, ok := present[path]; !ok || d.HIDParent != pad.parent {
+			m.touchpads.RemoveDevice(path)
+			delete(m.pads, path)
+			changed = true
+		}
+	}
+	for path, d := range present {
+		if _, ok := m.pads[path]; ok {
+			continue
+		}
+		if !m.touchpads.Has(path) {
+			if err := m.touchpads.AddDevice(&DeviceInfo{Path: path, Name: d.Name}); err != nil {
+				m.logger.Warn().Err(err).Str("device", path).Msg("Failed to add Bluetooth touchpad")
+				continue
+			}
+		}
+		m.pads[path] = &tracked{parent: d.HIDParent, name: d.Name}
+		changed = true
+	}
+	return changed
+}
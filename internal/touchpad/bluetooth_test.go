@@ -0,0 +1,117 @@
+package touchpad
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeKeyboard struct {
+	path    string
+	stopped bool
+}
+
+func (f *fakeKeyboard) Start(context.Context) error { return nil }
+func (f *fakeKeyboard) Stop() error                 { f.stopped = true; return nil }
+
+type fakeTouchpadSet struct {
+	mu    sync.Mutex
+	paths map[string]bool
+}
+
+func (f *fakeTouchpadSet) AddDevice(dev *DeviceInfo) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.paths[dev.Path] = true
+	return nil
+}
+
+func (f *fakeTouchpadSet) RemoveDevice(path string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.paths, path)
+}
+
+func (f *fakeTouchpadSet) Has(path string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.paths[path]
+}
+
+func (f *fakeTouchpadSet) list() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var paths []string
+	for p := range f.paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// unplug removes an event node from the fake tree.
+func unplug(t *testing.T, event string) {
+	t.Helper()
+	require.NoError(t, os.Remove(filepath.Join(inputDevDir, event)))
+	require.NoError(t, os.RemoveAll(filepath.Join(sysClassInput, event)))
+}
+
+func TestBluetoothManager_ConnectDisconnect(t *testing.T) {
+	fakeInputTree(t, map[string]fakeDevice{
+		"event5":  {name: "ASUE1409:00 04F3:3134 Touchpad", id: [4]string{"0018", "04f3", "3134", "0100"}},
+		"event12": {name: "ASUS Zenbook Duo Keyboard", id: [4]string{"0005", "0b05", "1b2d", "0001"}, parent: "0005:0B05:1B2D.0003"},
+		"event13": {name: "ASUS Zenbook Duo Keyboard Touchpad", id: [4]string{"0005", "0b05", "1b2d", "0001"}, parent: "0005:0B05:1B2D.0003"},
+	})
+	pads := &fakeTouchpadSet{paths: map[string]bool{filepath.Join(inputDevDir, "event5"): true}}
+	changes := 0
+	m := NewBluetoothManager(pads, func(KeyEvent) {}, func() { changes++ }, zerolog.Nop())
+	var keyboards []*fakeKeyboard
+	m.newKeyboard = func(path string) keyboardSource {
+		kb := &fakeKeyboard{path: path}
+		keyboards = append(keyboards, kb)
+		return kb
+	}
+	m.ctx = context.Background()
+
+	kbPath := filepath.Join(inputDevDir, "event12")
+	padPath := filepath.Join(inputDevDir, "event13")
+
+	m.poll()
+	require.Len(t, keyboards, 1)
+	assert.Equal(t, kbPath, keyboards[0].path)
+	assert.ElementsMatch(t, []string{filepath.Join(inputDevDir, "event5"), padPath}, pads.list())
+	assert.Equal(t, []BluetoothPair{{Keyboard: kbPath, Name: "ASUS Zenbook Duo Keyboard", Touchpads: []string{padPath}}}, m.Pairs())
+	assert.Equal(t, 1, changes)
+
+	m.poll()
+	assert.Len(t, keyboards, 1, "nothing changes while connected")
+	assert.Equal(t, 1, changes)
+
+	// Disconnect
+	unplug(t, "event12")
+	unplug(t, "event13")
+	m.poll()
+	assert.True(t, keyboards[0].stopped)
+	assert.Equal(t, []string{filepath.Join(inputDevDir, "event5")}, pads.list(), "built-in touchpad is left alone")
+	assert.Empty(t, m.Pairs())
+	assert.Equal(t, 2, changes)
+
+	// Reconnect on the same event nodes under a new HID device
+	fakeInputTree(t, map[string]fakeDevice{
+		"event12": {name: "ASUS Zenbook Duo Keyboard", id: [4]string{"0005", "0b05", "1b2d", "0001"}, parent: "0005:0B05:1B2D.0004"},
+		"event13": {name: "ASUS Zenbook Duo Keyboard Touchpad", id: [4]string{"0005", "0b05", "1b2d", "0001"}, parent: "0005:0B05:1B2D.0004"},
+	})
+	kbPath = filepath.Join(inputDevDir, "event12")
+	m.poll()
+	require.Len(t, keyboards, 2)
+	assert.Equal(t, kbPath, keyboards[1].path)
+	assert.Len(t, m.Pairs(), 1)
+	assert.Equal(t, 3, changes)
+}
@@ -0,0 +1,158 @@
+package touchpad
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	evdev "github.com/holoplot/go-evdev"
+)
+
+// DeviceClass is the role discovery assigns to an input device.
+type DeviceClass string
+
+const (
+	// ClassTouchpad devices are grabbed while typing.
+	ClassTouchpad DeviceClass = "touchpad"
+	// ClassKeyboard devices are monitored for typing activity.
+	ClassKeyboard DeviceClass = "keyboard"
+	// ClassIgnored devices are not used by the daemon.
+	ClassIgnored DeviceClass = "ignored"
+)
+
+// Classification explains how discovery treats a device and why.
+type Classification struct {
+	Class DeviceClass `json:"class"`
+	Rule  string      `json:"rule"`
+}
+
+// Classify applies the discovery rules to a device name.
+func Classify(name string) Classification {
+	if strings.HasPrefix(name, virtualPrefix) {
+		return Classification{Class: ClassIgnored, Rule: "created by palm-reject-daemon"}
+	}
+	if rule := touchpadRule(name); rule != "" {
+		return Classification{Class: ClassTouchpad, Rule: rule}
+	}
+	if rule := keyboardRule(name); rule != "" {
+		return Classification{Class: ClassKeyboard, Rule: rule}
+	}
+	return Classification{Class: ClassIgnored, Rule: "no rule matched"}
+}
+
+// classifyDevice is Classify with the bus taken into account: Bluetooth
+// keyboards are hot-plugged and carry no fixed name, so any Bluetooth
+// device calling itself a keyboard is used for typing detection.
+func classifyDevice(name string, id InputID) Classification {
+	c := Classify(name)
+	if c.Class == ClassIgnored && isBluetoothKeyboard(name, id) {
+		return Classification{Class: ClassKeyboard, Rule: `bluetooth and name contains "keyboard"`}
+	}
+	return c
+}
+
+func isBluetoothKeyboard(name string, id InputID) bool {
+	lower := strings.ToLower(name)
+	// HID keyboards also expose media/power keys as separate
+	// "... Consumer Control" and "... System Control" nodes; those are not typing
+	if strings.HasSuffix(lower, " consumer control") || strings.HasSuffix(lower, " system control") {
+		return false
+	}
+	return id.BusType == uint16(evdev.BUS_BLUETOOTH) && strings.Contains(lower, "keyboard")
+}
+
+// InputDevice describes one /dev/input/event* node as seen through sysfs.
+type InputDevice struct {
+	// Path is the device path (e.g., /dev/input/event5)
+	Path string `json:"path"`
+	// Name is the device name from sysfs
+	Name string `json:"name"`
+	// ID is the bus, vendor and product of the device
+	ID InputID `json:"id"`
+	// Capabilities lists the supported event types (EV_KEY, EV_ABS, ...)
+	Capabilities []string `json:"capabilities"`
+	// Properties lists the input properties (INPUT_PROP_BUTTONPAD, ...)
+	Properties []string `json:"properties,omitempty"`
+	// HIDParent is the sysfs path of the HID device the node belongs to;
+	// a keyboard and its touchpad share it.
+	HIDParent string `json:"hid_parent,omitempty"`
+	// Group is the physical device the node belongs to (see DeviceGroup)
+	Group string `json:"group,omitempty"`
+	Classification
+	// Selected is true if the daemon would use this device: every touchpad,
+	// and the one keyboard FindKeyboardDevice picks.
+	Selected bool `json:"selected"`
+}
+
+// ScanInputDevices lists every event node in numeric order with its
+// classification, without opening any device.
+func ScanInputDevices() ([]*InputDevice, error) {
+	entries, err := os.ReadDir(inputDevDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", inputDevDir, err)
+	}
+
+	var devices []*InputDevice
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "event") {
+			continue
+		}
+		name := getDeviceNameFromSysfs(entry.Name())
+		id := readInputID(entry.Name())
+		parent := readHIDParent(entry.Name())
+		caps, props := readCapabilities(entry.Name())
+		devices = append(devices, &InputDevice{
+			Path:           filepath.Join(inputDevDir, entry.Name()),
+			Name:           name,
+			ID:             id,
+			Capabilities:   caps,
+			Properties:     props,
+			HIDParent:      parent,
+			Group:          physicalDevice(parent),
+			Classification: classifyDevice(name, id),
+		})
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		return eventNumber(devices[i].Path) < eventNumber(devices[j].Path)
+	})
+	markSelected(devices)
+	return devices, nil
+}
+
+// markSelected mirrors the choices of FindAllTouchpadDevices,
+// FindKeyboardDevice and BluetoothManager: all touchpads, every Bluetooth
+// keyboard, and keyd's virtual keyboard in preference to the first AT keyboard.
+func markSelected(devices []*InputDevice) {
+	var keyboard *InputDevice
+	for _, d := range devices {
+		switch {
+		case d.Class == ClassTouchpad:
+			d.Selected = true
+		case d.Class != ClassKeyboard:
+		case isBluetoothKeyboard(d.Name, d.ID):
+			d.Selected = true
+		case strings.Contains(d.Name, "keyd virtual keyboard"):
+			if keyboard == nil || !strings.Contains(keyboard.Name, "keyd virtual keyboard") {
+				keyboard = d
+			}
+		case keyboard == nil:
+			keyboard = d
+		}
+	}
+	if keyboard != nil {
+		keyboard.Selected = true
+	}
+}
+
+// eventNumber extracts N from /dev/input/eventN, or -1.
+func eventNumber(path string) int {
+	n, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(path), "event"))
+	if err != nil {
+		return -1
+	}
+	return n
+}
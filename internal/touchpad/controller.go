@@ -2,10 +2,14 @@ package touchpad
 
 import (
 	"fmt"
+	"os"
 	"sync"
+	"time"
 
 	evdev "github.com/holoplot/go-evdev"
 	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/metrics"
 )
 
 // TouchpadController is the interface for touchpad control.
@@ -17,6 +21,13 @@ type TouchpadController interface {
 	Stop() error
 }
 
+// KeyboardRouter is implemented by controllers that can suppress only the
+// touchpads belonging to the keyboard being typed on (see DeviceGroup).
+// MultiController implements it.
+type KeyboardRouter interface {
+	DisableFor(keyboard string) error
+}
+
 // Controller manages touchpad enable/disable state using evdev GRAB.
 // When grabbed, the touchpad device is exclusively owned by this process,
 // preventing events from reaching other applications.
@@ -26,14 +37,25 @@ type Controller struct {
 	grabbed    bool
 	mu         sync.Mutex
 	logger     zerolog.Logger
+	metrics    *metrics.Metrics
+	intent     IntentCriteria
+	onIntent   func(path, reason string)
+	// suppression is what the grab still lets through; forward passes it
+	// on to the virtual device when that could be created
+	suppression Suppression
+	taps        TapCriteria
+	virtual     *virtualDevice
+	forward     forwarder
 }
 
 // NewController creates a new touchpad controller.
 // The device is not opened until Open() is called.
 func NewController(devicePath string, logger zerolog.Logger) *Controller {
 	return &Controller{
-		devicePath: devicePath,
-		logger:     logger.With().Str("component", "touchpad_ctrl").Str("device", devicePath).Logger(),
+		devicePath:  devicePath,
+		suppression: SuppressGrab,
+		taps:        DefaultTapCriteria,
+		logger:      logger.With().Str("component", "touchpad_ctrl").Str("device", devicePath).Logger(),
 	}
 }
 
@@ -47,7 +69,7 @@ func (c *Controller) Open() error {
 		return nil // Already open
 	}
 
-	dev, err := evdev.Open(c.devicePath)
+	dev, err := openInputDevice(c.devicePath, os.O_RDWR)
 	if err != nil {
 		return fmt.Errorf("failed to open touchpad device %s: %w", c.devicePath, err)
 	}
@@ -59,10 +81,101 @@ func (c *Controller) Open() error {
 	}
 
 	c.device = dev
-	c.logger.Info().Str("name", name).Msg("Touchpad controller opened")
+	c.logger.Info().Str("name", name).Str("suppression", string(c.suppression)).Msg("Touchpad controller opened")
+	abs, err := dev.AbsInfos()
+	if err != nil {
+		abs = map[evdev.EvCode]evdev.AbsInfo{}
+	}
+	switch c.suppression {
+	case SuppressClicks:
+		if virtual, err := newVirtualMouse(name); err != nil {
+			c.logger.Warn().Err(err).Msg("Cannot create the virtual mouse for clicks; they are suppressed too")
+		} else {
+			c.virtual, c.forward = virtual, newClickForwarder(virtual)
+		}
+	case SuppressTaps:
+		if virtual, err := newVirtualTouchpad(name, dev); err != nil {
+			c.logger.Warn().Err(err).Msg("Cannot create the virtual touchpad; the touchpad is grabbed completely")
+		} else {
+			c.virtual, c.forward = virtual, newTapFilter(virtual, c.taps, abs)
+		}
+	}
+	var detector *intentDetector
+	if c.onIntent != nil {
+		detector = newIntentDetector(c.intentCriteria(), abs[evdev.ABS_X])
+	}
+	if detector != nil || c.forward != nil {
+		go c.readLoop(dev, detector)
+	}
 	return nil
 }
 
+// intentCriteria are the criteria for deliberate touches. A touchpad whose
+// clicks are passed on anyway does not end the grab on a click, which would
+// let go of the button just pressed and break click-and-drag.
+func (c *Controller) intentCriteria() IntentCriteria {
+	intent := c.intent
+	if c.forward != nil {
+		intent.Click = false
+	}
+	return intent
+}
+
+// SetOnIntent makes the controller watch for deliberate touches while it
+// is grabbed and report them, with the reason, to fn. Call before Open.
+func (c *Controller) SetOnIntent(criteria IntentCriteria, fn func(path, reason string)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.intent = criteria
+	c.onIntent = fn
+}
+
+// SetSuppression selects what the touchpad still lets through while it
+// is disabled, and for SuppressTaps what a tap is. Call before Open.
+func (c *Controller) SetSuppression(s Suppression, taps TapCriteria) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.suppression, c.taps = s, taps
+}
+
+// readLoop watches the touchpad for deliberate touches, if detector is
+// set, and passes on what the suppression lets through until the device is
+// closed. It has to read the controller's own descriptor: a grab delivers
+// every event of the device to the grabbing descriptor only, so a second,
+// non-grabbing reader would see nothing while the touchpad is suppressed.
+// Events read while not grabbed are discarded; other readers get their own
+// copy then.
+func (c *Controller) readLoop(device *evdev.InputDevice, detector *intentDetector) {
+	for {
+		ev, err := device.ReadOne()
+		if err != nil {
+			// Closed, or the device went away; Close and RemoveDevice
+			// take care of the rest
+			return
+		}
+		c.mu.Lock()
+		grabbed := c.grabbed && c.device == device
+		if c.forward != nil && c.device == device {
+			if err := c.forward.handle(ev, grabbed); err != nil {
+				c.logger.Warn().Err(err).Msg("Failed to pass on touchpad events")
+			}
+		}
+		c.mu.Unlock()
+		if detector == nil {
+			continue
+		}
+		if !grabbed {
+			detector.reset()
+			continue
+		}
+		if reason, ok := detector.handle(ev, time.Now()); ok {
+			detector.reset()
+			c.logger.Debug().Str("reason", reason).Msg("Deliberate touch while suppressed")
+			c.onIntent(c.devicePath, reason)
+		}
+	}
+}
+
 // Close closes the touchpad device.
 // If the touchpad is currently disabled, it will be re-enabled first.
 func (c *Controller) Close() error {
@@ -81,6 +194,13 @@ func (c *Controller) Close() error {
 		c.grabbed = false
 	}
 
+	if c.virtual != nil {
+		if err := c.virtual.Close(); err != nil {
+			c.logger.Warn().Err(err).Msg("Failed to remove the virtual device")
+		}
+		c.virtual, c.forward = nil, nil
+	}
+
 	err := c.device.Close()
 	c.device = nil
 	c.logger.Info().Msg("Touchpad controller closed")
@@ -101,7 +221,10 @@ func (c *Controller) Disable() error {
 		return nil // Already disabled
 	}
 
-	if err := c.device.Grab(); err != nil {
+	start := time.Now()
+	err := c.device.Grab()
+	c.metrics.ObserveDeviceOp(c.devicePath, "grab", time.Since(start), err)
+	if err != nil {
 		return fmt.Errorf("failed to grab touchpad: %w", err)
 	}
 
@@ -123,15 +246,30 @@ func (c *Controller) Enable() error {
 		return nil // Already enabled
 	}
 
-	if err := c.device.Ungrab(); err != nil {
+	start := time.Now()
+	err := c.device.Ungrab()
+	c.metrics.ObserveDeviceOp(c.devicePath, "ungrab", time.Since(start), err)
+	if err != nil {
 		return fmt.Errorf("failed to ungrab touchpad: %w", err)
 	}
 
 	c.grabbed = false
+	if c.forward != nil {
+		if err := c.forward.release(); err != nil {
+			c.logger.Warn().Err(err).Msg("Failed to release what was passed on")
+		}
+	}
 	c.logger.Debug().Msg("Touchpad enabled (ungrabbed)")
 	return nil
 }
 
+// SetMetrics enables grab/ungrab latency and error instrumentation.
+func (c *Controller) SetMetrics(m *metrics.Metrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metrics = m
+}
+
 // IsDisabled returns whether the touchpad is currently disabled.
 func (c *Controller) IsDisabled() bool {
 	c.mu.Lock()
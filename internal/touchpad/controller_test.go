@@ -57,6 +57,15 @@ func TestController_StateManagement(t *testing.T) {
 	assert.False(t, controller.IsDisabled())
 }
 
+func TestController_IntentCriteria(t *testing.T) {
+	controller := NewController("/dev/input/event5", zerolog.Nop())
+	controller.SetOnIntent(IntentCriteria{Fingers: 2, Click: true}, func(string, string) {})
+	assert.True(t, controller.intentCriteria().Click)
+
+	controller.forward = newClickForwarder(&fakeWriter{})
+	assert.Equal(t, IntentCriteria{Fingers: 2}, controller.intentCriteria(), "clicks are passed on, not taken as intent")
+}
+
 func TestController_DeviceInfo(t *testing.T) {
 	info := &DeviceInfo{
 		Path: "/dev/input/event5",
@@ -65,4 +74,52 @@ func TestController_DeviceInfo(t *testing.T) {
 
 	assert.Equal(t, "/dev/input/event5", info.Path)
 	assert.Equal(t, "ELAN1200:00 Touchpad", info.Name)
-}
\ No newline at end of file
+}
+
+func TestMultiController_SetActive(t *testing.T) {
+	multi := NewMultiController([]*DeviceInfo{
+		{Path: "/dev/input/event5"},
+		{Path: "/dev/input/event7"},
+	}, zerolog.Nop())
+
+	assert.Equal(t, []string{"/dev/input/event5", "/dev/input/event7"}, multi.ActivePaths())
+
+	multi.SetActive([]string{"/dev/input/event7"})
+	assert.Equal(t, []string{"/dev/input/event7"}, multi.ActivePaths())
+
+	// With no active touchpad there is nothing to grab
+	multi.SetActive([]string{})
+	assert.Empty(t, multi.ActivePaths())
+	assert.NoError(t, multi.Disable())
+	assert.False(t, multi.IsDisabled())
+
+	multi.SetActive(nil)
+	assert.Len(t, multi.ActivePaths(), 2)
+}
+
+func TestMultiController_TargetsFor(t *testing.T) {
+	multi := NewMultiController([]*DeviceInfo{
+		{Path: "/dev/input/event5"},  // built-in touchpad
+		{Path: "/dev/input/event13"}, // Bluetooth keyboard's touchpad
+	}, zerolog.Nop())
+	multi.SetGroups([]*DeviceGroup{
+		{ID: "bt", Keyboards: []string{"/dev/input/event12"}, Touchpads: []string{"/dev/input/event13"}},
+		{ID: "i2c", Keyboards: []string{}, Touchpads: []string{"/dev/input/event5"}},
+	})
+
+	paths := func(ctrls []*Controller) []string {
+		var p []string
+		for _, c := range ctrls {
+			p = append(p, c.DevicePath())
+		}
+		return p
+	}
+
+	assert.Equal(t, []string{"/dev/input/event13"}, paths(multi.targetsFor("/dev/input/event12")))
+	assert.Equal(t, []string{"/dev/input/event5", "/dev/input/event13"}, paths(multi.targetsFor("/dev/input/event2")),
+		"a keyboard without a touchpad of its own suppresses all")
+
+	// The dock policy deactivated the keyboard's touchpad
+	multi.SetActive([]string{"/dev/input/event5"})
+	assert.Equal(t, []string{"/dev/input/event5"}, paths(multi.targetsFor("/dev/input/event12")))
+}
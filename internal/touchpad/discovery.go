@@ -10,7 +10,7 @@ import (
 	"github.com/rs/zerolog"
 )
 
-const (
+var (
 	// inputDevDir is the directory containing input event devices
 	inputDevDir = "/dev/input"
 	// sysClassInput is the sysfs path for input device information
@@ -155,12 +155,30 @@ func getDeviceNameFromSysfs(eventName string) string {
 
 // isTouchpadDevice checks if the device name indicates a touchpad.
 func isTouchpadDevice(name string) bool {
+	return touchpadRule(name) != ""
+}
+
+// virtualPrefix starts the names of the virtual devices the daemon creates
+// to pass events on; they must never be taken for a touchpad to control.
+const virtualPrefix = "palm-reject "
+
+// touchpadRule returns the rule that identifies name as a touchpad, or ""
+// if none matches.
+func touchpadRule(name string) string {
 	nameLower := strings.ToLower(name)
 	// Check for common touchpad identifiers
-	return strings.Contains(nameLower, "touchpad") ||
-		strings.Contains(nameLower, "trackpad") ||
-		// ASUS-specific: the integrated touchpad may have specific names
-		(strings.Contains(nameLower, "asus") && strings.Contains(nameLower, "touch"))
+	switch {
+	case strings.HasPrefix(name, virtualPrefix):
+		return ""
+	case strings.Contains(nameLower, "touchpad"):
+		return `name contains "touchpad"`
+	case strings.Contains(nameLower, "trackpad"):
+		return `name contains "trackpad"`
+	// ASUS-specific: the integrated touchpad may have specific names
+	case strings.Contains(nameLower, "asus") && strings.Contains(nameLower, "touch"):
+		return `name contains "asus" and "touch"`
+	}
+	return ""
 }
 
 // isKeyboardDevice checks if the device name indicates a keyboard for typing detection.
@@ -168,17 +186,23 @@ func isTouchpadDevice(name string) bool {
 // 1. "keyd virtual keyboard" - if keyd is running, it grabs the physical keyboard
 // 2. "AT Translated Set 2 keyboard" - standard internal keyboard on most laptops
 func isKeyboardDevice(name string) bool {
+	return keyboardRule(name) != ""
+}
+
+// keyboardRule returns the rule that identifies name as a typing keyboard,
+// or "" if none matches.
+func keyboardRule(name string) string {
 	// keyd virtual keyboard - if keyd (key remapper) is running, it GRABs the physical
 	// keyboard and emits events through this virtual device
 	if strings.Contains(name, "keyd virtual keyboard") {
-		return true
+		return `name contains "keyd virtual keyboard" (preferred)`
 	}
 	// Standard internal keyboard - receives all regular keypresses (a-z, numbers, etc.)
 	// This is the primary keyboard device on most laptops
 	if strings.Contains(name, "AT Translated Set 2 keyboard") {
-		return true
+		return `name contains "AT Translated Set 2 keyboard" (fallback)`
 	}
-	return false
+	return ""
 }
 
 // IsTouchpadPresent checks if a touchpad device exists.
@@ -1,9 +1,12 @@
 package touchpad
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsTouchpadDevice(t *testing.T) {
@@ -21,6 +24,7 @@ func TestIsTouchpadDevice(t *testing.T) {
 		{"Mouse device", "Logitech USB Mouse", false},
 		{"Empty string", "", false},
 		{"ASUS but no touch", "ASUE140A:00 04F3:3134 Keyboard", false},
+		{"Own virtual device", "palm-reject clicks: ELAN1200:00 04F3:307A Touchpad", false},
 	}
 
 	for _, tt := range tests {
@@ -52,10 +56,117 @@ func TestIsKeyboardDevice(t *testing.T) {
 	}
 }
 
+// fakeDevice describes an event node for fakeInputTree.
+type fakeDevice struct {
+	name   string
+	id     [4]string // bustype, vendor, product, version
+	ev     string
+	props  string
+	parent string // HID device the node hangs off, e.g. "0005:0B05:1B2D.0003"
+}
+
+// fakeInputTree creates /dev/input and /sys/class/input look-alikes and
+// points the package at them for the duration of the test.
+func fakeInputTree(t *testing.T, devices map[string]fakeDevice) {
+	t.Helper()
+	devDir := t.TempDir()
+	sysDir := t.TempDir()
+	for event, d := range devices {
+		require.NoError(t, os.WriteFile(filepath.Join(devDir, event), nil, 0600))
+		dev := filepath.Join(sysDir, event, "device")
+		require.NoError(t, os.MkdirAll(filepath.Join(dev, "id"), 0755))
+		require.NoError(t, os.MkdirAll(filepath.Join(dev, "capabilities"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dev, "name"), []byte(d.name+"\n"), 0644))
+		for i, field := range []string{"bustype", "vendor", "product", "version"} {
+			require.NoError(t, os.WriteFile(filepath.Join(dev, "id", field), []byte(d.id[i]+"\n"), 0644))
+		}
+		require.NoError(t, os.WriteFile(filepath.Join(dev, "capabilities", "ev"), []byte(d.ev+"\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dev, "properties"), []byte(d.props+"\n"), 0644))
+		if d.parent != "" {
+			hid := filepath.Join(sysDir, "devices", d.parent)
+			require.NoError(t, os.MkdirAll(hid, 0755))
+			require.NoError(t, os.Symlink(hid, filepath.Join(dev, "device")))
+		}
+	}
+
+	oldDev, oldSys := inputDevDir, sysClassInput
+	inputDevDir, sysClassInput = devDir, sysDir
+	t.Cleanup(func() { inputDevDir, sysClassInput = oldDev, oldSys })
+}
+
 func TestGetDeviceNameFromSysfs(t *testing.T) {
-	// Skip this test if running in an environment with real devices
-	// as we can't easily mock the filesystem path
-	t.Skip("Cannot mock sysfs path in current implementation")
+	fakeInputTree(t, map[string]fakeDevice{
+		"event5": {name: "ELAN1200:00 Touchpad"},
+	})
+
+	assert.Equal(t, "ELAN1200:00 Touchpad", getDeviceNameFromSysfs("event5"))
+	assert.Equal(t, "", getDeviceNameFromSysfs("event99"))
+}
+
+func TestScanInputDevices(t *testing.T) {
+	fakeInputTree(t, map[string]fakeDevice{
+		"event2":  {name: "AT Translated Set 2 keyboard", id: [4]string{"0011", "0001", "0001", "ab83"}, ev: "120013"},
+		"event10": {name: "ASUE1409:00 04F3:3134 Touchpad", id: [4]string{"0018", "04f3", "3134", "0100"}, ev: "1b", props: "5"},
+		"event11": {name: "keyd virtual keyboard", id: [4]string{"0006", "0fac", "0ade", "0001"}, ev: "120013"},
+		"event3":  {name: "Logitech USB Mouse", id: [4]string{"0003", "046d", "c077", "0111"}, ev: "17"},
+	})
+
+	devices, err := ScanInputDevices()
+	require.NoError(t, err)
+	require.Len(t, devices, 4)
+
+	// Numeric, not lexical, order
+	assert.Equal(t, filepath.Join(inputDevDir, "event2"), devices[0].Path)
+	assert.Equal(t, filepath.Join(inputDevDir, "event3"), devices[1].Path)
+	assert.Equal(t, filepath.Join(inputDevDir, "event10"), devices[2].Path)
+
+	at, mouse, pad, keyd := devices[0], devices[1], devices[2], devices[3]
+	assert.Equal(t, "i8042 0001:0001", at.ID.String())
+	assert.Equal(t, []string{"EV_SYN", "EV_KEY", "EV_MSC", "EV_LED", "EV_REP"}, at.Capabilities)
+	assert.Equal(t, ClassKeyboard, at.Class)
+	assert.False(t, at.Selected, "keyd is preferred over the AT keyboard")
+
+	assert.Equal(t, ClassIgnored, mouse.Class)
+	assert.False(t, mouse.Selected)
+
+	assert.Equal(t, ClassTouchpad, pad.Class)
+	assert.True(t, pad.Selected)
+	assert.Equal(t, []string{"INPUT_PROP_POINTER", "INPUT_PROP_BUTTONPAD"}, pad.Properties)
+	assert.Equal(t, "i2c 04f3:3134", pad.ID.String())
+
+	assert.True(t, keyd.Selected)
+}
+
+func TestScanInputDevices_Bluetooth(t *testing.T) {
+	fakeInputTree(t, map[string]fakeDevice{
+		"event12": {name: "ASUS Zenbook Duo Keyboard", id: [4]string{"0005", "0b05", "1b2d", "0001"}, ev: "120013", parent: "0005:0B05:1B2D.0003"},
+		"event13": {name: "ASUS Zenbook Duo Keyboard Touchpad", id: [4]string{"0005", "0b05", "1b2d", "0001"}, ev: "1b", parent: "0005:0B05:1B2D.0003"},
+		"event14": {name: "Logitech MX Keys Consumer Control", id: [4]string{"0005", "046d", "b35b", "0001"}, ev: "1f"},
+	})
+
+	devices, err := ScanInputDevices()
+	require.NoError(t, err)
+	require.Len(t, devices, 3)
+
+	kb, pad, other := devices[0], devices[1], devices[2]
+	assert.Equal(t, ClassKeyboard, kb.Class)
+	assert.Equal(t, `bluetooth and name contains "keyboard"`, kb.Rule)
+	assert.True(t, kb.Selected)
+	assert.Equal(t, "bluetooth 0b05:1b2d", kb.ID.String())
+
+	assert.Equal(t, ClassTouchpad, pad.Class)
+	assert.NotEmpty(t, kb.HIDParent)
+	assert.Equal(t, kb.HIDParent, pad.HIDParent, "keyboard and touchpad share the HID device")
+
+	assert.Equal(t, ClassIgnored, other.Class)
+	assert.Empty(t, other.HIDParent)
+}
+
+func TestParseBitmap(t *testing.T) {
+	assert.Equal(t, []int{0, 1, 4}, parseBitmap("13"))
+	assert.Equal(t, []int{1, 64}, parseBitmap("1 2"))
+	assert.Nil(t, parseBitmap("zz"))
+	assert.Nil(t, parseBitmap(""))
 }
 
 func TestDeviceInfo(t *testing.T) {
@@ -81,4 +192,32 @@ func TestDiscoveryWithLogger(t *testing.T) {
 			IsKeyboardPresent()
 		})
 	})
-}
\ No newline at end of file
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		device string
+		class  DeviceClass
+		rule   string
+	}{
+		{"ASUE140A:00 04F3:3134 Touchpad", ClassTouchpad, `name contains "touchpad"`},
+		{"Apple Inc. Magic Trackpad", ClassTouchpad, `name contains "trackpad"`},
+		{"keyd virtual keyboard", ClassKeyboard, `name contains "keyd virtual keyboard" (preferred)`},
+		{"AT Translated Set 2 keyboard", ClassKeyboard, `name contains "AT Translated Set 2 keyboard" (fallback)`},
+		{"Logitech USB Mouse", ClassIgnored, "no rule matched"},
+		{"palm-reject clicks: ASUE140A:00 04F3:3134 Touchpad", ClassIgnored, "created by palm-reject-daemon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.device, func(t *testing.T) {
+			c := Classify(tt.device)
+			assert.Equal(t, tt.class, c.Class)
+			assert.Equal(t, tt.rule, c.Rule)
+		})
+	}
+}
+
+func TestEventNumber(t *testing.T) {
+	assert.Equal(t, 12, eventNumber("/dev/input/event12"))
+	assert.Equal(t, -1, eventNumber("/dev/input/mice"))
+}
@@ -0,0 +1,13 @@
+package touchpad
+
+import (
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+)
+
+// HandleKey feeds a key event to the monitor as if read from the device,
+// for tests outside the package.
+func (m *KeyboardMonitor) HandleKey(code evdev.EvCode, action KeyAction, at time.Time) {
+	m.handleKey(code, action, at)
+}
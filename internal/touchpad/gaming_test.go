@@ -0,0 +1,68 @@
+package touchpad_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/consumer"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/events"
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/touchpad"
+)
+
+// recordingController records whether the touchpad is grabbed.
+type recordingController struct {
+	mu       sync.Mutex
+	disabled bool
+}
+
+func (c *recordingController) Disable() error { c.set(true); return nil }
+func (c *recordingController) Enable() error  { c.set(false); return nil }
+func (c *recordingController) Stop() error    { return nil }
+
+func (c *recordingController) IsDisabled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.disabled
+}
+
+func (c *recordingController) set(disabled bool) {
+	c.mu.Lock()
+	c.disabled = disabled
+	c.mu.Unlock()
+}
+
+func TestKeyboardMonitor_GamingMode(t *testing.T) {
+	bus := events.NewSystemEventBus(zerolog.Nop())
+	defer bus.Close()
+
+	gaming := consumer.NewGamingModeConsumer([]evdev.EvCode{evdev.KEY_W}, bus, zerolog.Nop())
+	require.NoError(t, gaming.Start(context.Background()))
+	defer gaming.Stop()
+
+	ctrl := &recordingController{}
+	typing := consumer.NewTypingDetectionConsumer(nil, ctrl, bus, time.Hour, zerolog.Nop())
+	m := touchpad.NewKeyboardMonitor("/dev/input/event3", typing.OnKeyEvent, zerolog.Nop())
+	m.SetKeyFilter(gaming)
+	at := time.Now()
+
+	bus.Publish(events.GamingModeOn)
+	require.Eventually(t, func() bool { return gaming.Status().On }, time.Second, 5*time.Millisecond)
+
+	m.HandleKey(evdev.KEY_W, touchpad.KeyPress, at)
+	assert.False(t, ctrl.IsDisabled(), "an exempt key does not suppress the touchpad")
+	m.HandleKey(evdev.KEY_W, touchpad.KeyRelease, at)
+
+	m.HandleKey(evdev.KEY_E, touchpad.KeyPress, at)
+	assert.True(t, ctrl.IsDisabled(), "any other key still does")
+	assert.True(t, typing.IsDisabled())
+
+	require.NoError(t, typing.Stop())
+	assert.False(t, ctrl.IsDisabled())
+}
@@ -0,0 +1,48 @@
+package touchpad
+
+import "sort"
+
+// DeviceGroup is a physical device and the keyboards and touchpads it
+// exposes, e.g. the Duo keyboard with its built-in touchpad. Typing on a
+// keyboard only suppresses the touchpads of its own group.
+type DeviceGroup struct {
+	// ID is the sysfs path of the physical device
+	ID        string   `json:"id"`
+	Keyboards []string `json:"keyboards"`
+	Touchpads []string `json:"touchpads"`
+}
+
+// GroupDevices groups the keyboards and touchpads by physical device.
+// Devices without a known parent and groups without any keyboard or
+// touchpad are left out.
+func GroupDevices(devices []*InputDevice) []*DeviceGroup {
+	byID := make(map[string]*DeviceGroup)
+	var groups []*DeviceGroup
+	for _, d := range devices {
+		if d.Group == "" || (d.Class != ClassKeyboard && d.Class != ClassTouchpad) {
+			continue
+		}
+		g, ok := byID[d.Group]
+		if !ok {
+			g = &DeviceGroup{ID: d.Group, Keyboards: []string{}, Touchpads: []string{}}
+			byID[d.Group] = g
+			groups = append(groups, g)
+		}
+		if d.Class == ClassKeyboard {
+			g.Keyboards = append(g.Keyboards, d.Path)
+		} else {
+			g.Touchpads = append(g.Touchpads, d.Path)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].ID < groups[j].ID })
+	return groups
+}
+
+// ScanDeviceGroups scans the input devices and groups them.
+func ScanDeviceGroups() ([]*DeviceGroup, error) {
+	devices, err := ScanInputDevices()
+	if err != nil {
+		return nil, err
+	}
+	return GroupDevices(devices), nil
+}
@@ -0,0 +1,53 @@
+package touchpad
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhysicalDevice(t *testing.T) {
+	tests := []struct {
+		hidParent string
+		want      string
+	}{
+		{"/sys/devices/pci0000:00/0000:00:14.0/usb3/3-5/3-5:1.0/0003:0B05:1B2C.0001", "/sys/devices/pci0000:00/0000:00:14.0/usb3/3-5"},
+		{"/sys/devices/pci0000:00/0000:00:14.0/usb3/3-5/3-5.2/3-5.2:1.1/0003:0B05:1B2C.0004", "/sys/devices/pci0000:00/0000:00:14.0/usb3/3-5/3-5.2"},
+		{"/sys/devices/virtual/misc/uhid/0005:0B05:1B2D.0003", "/sys/devices/virtual/misc/uhid/0005:0B05:1B2D.0003"},
+		{"/sys/devices/pci0000:00/0000:00:15.0/i2c_designware.0/i2c-1/i2c-ASUE1409:00/0018:04F3:3134.0002", "/sys/devices/pci0000:00/0000:00:15.0/i2c_designware.0/i2c-1/i2c-ASUE1409:00/0018:04F3:3134.0002"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, physicalDevice(tt.hidParent), tt.hidParent)
+	}
+}
+
+func TestGroupDevices(t *testing.T) {
+	fakeInputTree(t, map[string]fakeDevice{
+		"event2":  {name: "AT Translated Set 2 keyboard", id: [4]string{"0011", "0001", "0001", "ab83"}},
+		"event5":  {name: "ASUE1409:00 04F3:3134 Touchpad", id: [4]string{"0018", "04f3", "3134", "0100"}, parent: "0018:04F3:3134.0002"},
+		"event12": {name: "ASUS Zenbook Duo Keyboard", id: [4]string{"0005", "0b05", "1b2d", "0001"}, parent: "0005:0B05:1B2D.0003"},
+		"event13": {name: "ASUS Zenbook Duo Keyboard Touchpad", id: [4]string{"0005", "0b05", "1b2d", "0001"}, parent: "0005:0B05:1B2D.0003"},
+		"event14": {name: "ASUS Zenbook Duo Keyboard Consumer Control", id: [4]string{"0005", "0b05", "1b2d", "0001"}, parent: "0005:0B05:1B2D.0003"},
+	})
+
+	groups, err := ScanDeviceGroups()
+	require.NoError(t, err)
+	require.Len(t, groups, 2, "the AT keyboard has no parent and is not grouped")
+
+	byTouchpad := make(map[string]*DeviceGroup)
+	for _, g := range groups {
+		for _, tp := range g.Touchpads {
+			byTouchpad[tp] = g
+		}
+	}
+
+	bt := byTouchpad[inputDevDir+"/event13"]
+	require.NotNil(t, bt)
+	assert.Equal(t, []string{inputDevDir + "/event12"}, bt.Keyboards, "consumer control is neither keyboard nor touchpad")
+
+	builtin := byTouchpad[inputDevDir+"/event5"]
+	require.NotNil(t, builtin)
+	assert.Empty(t, builtin.Keyboards)
+}
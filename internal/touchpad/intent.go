@@ -0,0 +1,114 @@
+package touchpad
+
+import (
+	"math"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+)
+
+// IntentCriteria decide when a touch on a suppressed touchpad is deliberate
+// rather than a palm, so the suppression can end early.
+type IntentCriteria struct {
+	// Fingers is the number of fingers down at once that counts as
+	// deliberate; 0 disables the check.
+	Fingers int
+	// Click makes a physical click (BTN_LEFT) deliberate.
+	Click bool
+	// MoveMM and MoveTime make a single contact deliberate once it has been
+	// down for MoveTime and travelled MoveMM millimetres; a MoveMM of 0
+	// disables the check.
+	MoveMM   float64
+	MoveTime time.Duration
+}
+
+// Reasons reported for a deliberate touch.
+const (
+	IntentFingers  = "fingers"
+	IntentClick    = "click"
+	IntentMovement = "movement"
+)
+
+// fingerTools maps the BTN_TOOL_* codes to the number of fingers they report.
+var fingerTools = map[evdev.EvCode]int{
+	evdev.BTN_TOOL_FINGER:    1,
+	evdev.BTN_TOOL_DOUBLETAP: 2,
+	evdev.BTN_TOOL_TRIPLETAP: 3,
+	evdev.BTN_TOOL_QUADTAP:   4,
+	evdev.BTN_TOOL_QUINTTAP:  5,
+}
+
+// intentDetector applies IntentCriteria to a touchpad's event stream.
+type intentDetector struct {
+	criteria IntentCriteria
+	// unitsPerMM converts ABS_X/ABS_Y to millimetres
+	unitsPerMM float64
+
+	touching bool
+	started  bool
+	start    time.Time
+	startX   int32
+	startY   int32
+	x, y     int32
+}
+
+// newIntentDetector creates a detector for a touchpad with the given ABS_X
+// axis.
+func newIntentDetector(criteria IntentCriteria, absX evdev.AbsInfo) *intentDetector {
+	return &intentDetector{criteria: criteria, unitsPerMM: unitsPerMM(absX)}
+}
+
+// unitsPerMM is the resolution of a touchpad's ABS_X axis. Axes without
+// one are assumed to be 100mm wide.
+func unitsPerMM(absX evdev.AbsInfo) float64 {
+	if absX.Resolution > 0 {
+		return float64(absX.Resolution)
+	}
+	return math.Max(float64(absX.Maximum-absX.Minimum)/100, 1)
+}
+
+// reset forgets the contact in progress.
+func (d *intentDetector) reset() {
+	d.touching, d.started = false, false
+}
+
+// handle feeds one event and returns the reason once the touch is
+// deliberate.
+func (d *intentDetector) handle(ev *evdev.InputEvent, at time.Time) (string, bool) {
+	switch ev.Type {
+	case evdev.EV_KEY:
+		if ev.Code == evdev.BTN_LEFT && ev.Value == 1 && d.criteria.Click {
+			return IntentClick, true
+		}
+		if n, ok := fingerTools[ev.Code]; ok && ev.Value == 1 && d.criteria.Fingers > 0 && n >= d.criteria.Fingers {
+			return IntentFingers, true
+		}
+		if ev.Code == evdev.BTN_TOUCH {
+			d.touching = ev.Value != 0
+			d.started = false
+		}
+
+	case evdev.EV_ABS:
+		switch ev.Code {
+		case evdev.ABS_X:
+			d.x = ev.Value
+		case evdev.ABS_Y:
+			d.y = ev.Value
+		}
+
+	case evdev.EV_SYN:
+		if ev.Code != evdev.SYN_REPORT || !d.touching || d.criteria.MoveMM <= 0 {
+			break
+		}
+		if !d.started {
+			d.started = true
+			d.start, d.startX, d.startY = at, d.x, d.y
+			break
+		}
+		dist := math.Hypot(float64(d.x-d.startX), float64(d.y-d.startY)) / d.unitsPerMM
+		if at.Sub(d.start) >= d.criteria.MoveTime && dist >= d.criteria.MoveMM {
+			return IntentMovement, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,66 @@
+package touchpad
+
+import (
+	"testing"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntentDetector(t *testing.T) {
+	criteria := IntentCriteria{Fingers: 2, Click: true, MoveMM: 10, MoveTime: 100 * time.Millisecond}
+	// 20 units per mm
+	d := newIntentDetector(criteria, evdev.AbsInfo{Maximum: 3000, Resolution: 20})
+	start := time.Unix(1700000000, 0)
+
+	feed := func(at time.Duration, events ...evdev.InputEvent) (string, bool) {
+		for _, ev := range events {
+			if reason, ok := d.handle(&ev, start.Add(at)); ok {
+				return reason, true
+			}
+		}
+		return "", false
+	}
+	key := func(code evdev.EvCode, value int32) evdev.InputEvent {
+		return evdev.InputEvent{Type: evdev.EV_KEY, Code: code, Value: value}
+	}
+	pos := func(x, y int32) []evdev.InputEvent {
+		return []evdev.InputEvent{
+			{Type: evdev.EV_ABS, Code: evdev.ABS_X, Value: x},
+			{Type: evdev.EV_ABS, Code: evdev.ABS_Y, Value: y},
+			{Type: evdev.EV_SYN, Code: evdev.SYN_REPORT},
+		}
+	}
+
+	// A resting palm: one contact, barely moving
+	_, ok := feed(0, append([]evdev.InputEvent{key(evdev.BTN_TOUCH, 1), key(evdev.BTN_TOOL_FINGER, 1)}, pos(1000, 500)...)...)
+	assert.False(t, ok)
+	_, ok = feed(500*time.Millisecond, pos(1040, 520)...)
+	assert.False(t, ok, "2mm is not deliberate")
+
+	// A quick swipe that is over before MoveTime
+	d.reset()
+	_, ok = feed(0, append([]evdev.InputEvent{key(evdev.BTN_TOUCH, 1)}, pos(1000, 500)...)...)
+	assert.False(t, ok)
+	_, ok = feed(50*time.Millisecond, pos(1400, 500)...)
+	assert.False(t, ok, "not sustained yet")
+	reason, ok := feed(120*time.Millisecond, pos(1400, 500)...)
+	assert.True(t, ok)
+	assert.Equal(t, IntentMovement, reason)
+
+	d.reset()
+	reason, ok = feed(0, key(evdev.BTN_TOOL_DOUBLETAP, 1))
+	assert.True(t, ok)
+	assert.Equal(t, IntentFingers, reason)
+
+	reason, ok = feed(0, key(evdev.BTN_LEFT, 1))
+	assert.True(t, ok)
+	assert.Equal(t, IntentClick, reason)
+
+	// Without a resolution the pad is assumed 100mm wide
+	d = newIntentDetector(IntentCriteria{MoveMM: 10}, evdev.AbsInfo{Maximum: 3000})
+	assert.Equal(t, 30.0, d.unitsPerMM)
+	_, ok = feed(0, key(evdev.BTN_LEFT, 1))
+	assert.False(t, ok, "clicks are not counted unless enabled")
+}
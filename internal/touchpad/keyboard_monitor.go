@@ -3,58 +3,173 @@ package touchpad
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	evdev "github.com/holoplot/go-evdev"
 	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/privacy"
 )
 
+// KeyAction is what happened to a key; the values are those of the evdev
+// event.
+type KeyAction int32
+
+const (
+	KeyRelease KeyAction = 0
+	KeyPress   KeyAction = 1
+	KeyRepeat  KeyAction = 2
+)
+
+// String returns the action's name.
+func (a KeyAction) String() string {
+	switch a {
+	case KeyRelease:
+		return "release"
+	case KeyPress:
+		return "press"
+	case KeyRepeat:
+		return "repeat"
+	}
+	return "unknown"
+}
+
+// KeyEvent is a key press, auto-repeat or release on a keyboard. Code is
+// the raw key code; what may be recorded about it is up to privacy.KeyPolicy.
+type KeyEvent struct {
+	Keyboard string
+	Code     evdev.EvCode
+	Action   KeyAction
+	// Time is the kernel's timestamp of the event.
+	Time time.Time
+}
+
+// KeyFilter decides which key presses do not count as typing.
+type KeyFilter interface {
+	Exempt(code evdev.EvCode) bool
+}
+
+// ParseKeys parses key names such as "KEY_W" or "w" into key codes.
+func ParseKeys(names []string) ([]evdev.EvCode, error) {
+	codes := make([]evdev.EvCode, 0, len(names))
+	for _, name := range names {
+		key := strings.ToUpper(strings.TrimSpace(name))
+		if !strings.HasPrefix(key, "KEY_") {
+			key = "KEY_" + key
+		}
+		code, ok := evdev.KEYFromString[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", name)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
 // KeyboardMonitor monitors a keyboard evdev device for typing activity.
 // This detects regular keypresses (a-z, numbers, etc.) - not the special Fn keys
-// that come through hidraw.
+// that come through hidraw (see the hidraw package).
 type KeyboardMonitor struct {
 	ctx        context.Context
 	cancel     context.CancelFunc
 	devicePath string
 	device     *evdev.InputDevice
-	onKeyPress func() // Callback when any key is pressed
+	onKey      func(KeyEvent) // Callback for typing key events
 	logger     zerolog.Logger
+	keys       privacy.KeyPolicy
+	filter     KeyFilter
+	chord      []evdev.EvCode
+	onChord    func()
+	lastPress  time.Time
+
+	// handlingSince is when the running onKey call started (UnixNano),
+	// 0 while reading
+	handlingSince atomic.Int64
+	// handleMu is held while an event is handled, so Stop can wait for it
+	handleMu sync.Mutex
+	mu       sync.Mutex
+	readErr  error
+	// held are the keys currently down and whether their press was
+	// reported, so only their repeats and release are
+	held map[evdev.EvCode]bool
 }
 
-// NewKeyboardMonitor creates a new keyboard monitor.
-func NewKeyboardMonitor(devicePath string, onKeyPress func(), logger zerolog.Logger) *KeyboardMonitor {
+// keyHandlerTimeout is how long onKey may run before the monitor reports
+// itself unhealthy.
+const keyHandlerTimeout = 5 * time.Second
+
+// NewKeyboardMonitor creates a new keyboard monitor reporting typing to
+// onKey: every press that is not exempt or part of the chord, and the
+// repeats and release of those keys.
+func NewKeyboardMonitor(devicePath string, onKey func(KeyEvent), logger zerolog.Logger) *KeyboardMonitor {
 	return &KeyboardMonitor{
 		devicePath: devicePath,
-		onKeyPress: onKeyPress,
+		onKey:      onKey,
+		held:       make(map[evdev.EvCode]bool),
 		logger:     logger.With().Str("component", "kb_monitor").Str("device", devicePath).Logger(),
 	}
 }
 
-// Start starts the keyboard monitor.
+// SetKeyPolicy sets what is logged about key presses; by default only the
+// key class and timing. Call before Start.
+func (m *KeyboardMonitor) SetKeyPolicy(p privacy.KeyPolicy) {
+	m.keys = p
+}
+
+// SetKeyFilter sets which key presses are not reported as typing. Call
+// before Start.
+func (m *KeyboardMonitor) SetKeyFilter(f KeyFilter) {
+	m.filter = f
+}
+
+// SetChord runs fn, instead of reporting typing, when every key in codes is
+// held down at once. Call before Start.
+func (m *KeyboardMonitor) SetChord(codes []evdev.EvCode, fn func()) {
+	m.chord = codes
+	m.onChord = fn
+}
+
+// Start starts the keyboard monitor. A stopped monitor may be started
+// again, e.g. once a paused session is active again.
 func (m *KeyboardMonitor) Start(ctx context.Context) error {
 	m.ctx, m.cancel = context.WithCancel(ctx)
 
 	// Open the evdev device
-	dev, err := evdev.Open(m.devicePath)
+	dev, err := openInputDevice(m.devicePath, os.O_RDWR)
 	if err != nil {
-		return fmt.Errorf("failed to open keyboard device %s: %w", m.devicePath, err)
+		// Unhealthy until a start succeeds, so a failed restart is noticed
+		err = fmt.Errorf("failed to open keyboard device %s: %w", m.devicePath, err)
+		m.setReadErr(err)
+		return err
 	}
 	m.device = dev
+	m.setReadErr(nil)
 
 	name, err := m.device.Name()
 	if err != nil {
 		m.device.Close()
-		return fmt.Errorf("failed to get device name: %w", err)
+		m.device = nil
+		err = fmt.Errorf("failed to get device name: %w", err)
+		m.setReadErr(err)
+		return err
 	}
 
 	m.logger.Info().Str("name", name).Msg("Keyboard monitor started")
 
-	// Start reading in a goroutine
-	go m.readLoop()
+	// Start reading in a goroutine; it keeps its own context and device so
+	// a restart cannot hand it the next ones
+	go m.readLoop(m.ctx, m.device)
 
 	return nil
 }
 
-// Stop stops the keyboard monitor.
+// Stop stops the keyboard monitor. The keys still held are reported as
+// released once the event being handled, if any, is done; the read loop
+// drops whatever it reads after that.
 func (m *KeyboardMonitor) Stop() error {
 	if m.cancel != nil {
 		m.cancel()
@@ -64,41 +179,179 @@ func (m *KeyboardMonitor) Stop() error {
 		m.device.Close()
 		m.device = nil
 	}
+	m.setReadErr(nil)
+	m.handleMu.Lock()
+	m.releaseAll()
+	m.handleMu.Unlock()
 
 	m.logger.Info().Msg("Keyboard monitor stopped")
 	return nil
 }
 
 // readLoop reads events from the keyboard evdev device.
-func (m *KeyboardMonitor) readLoop() {
+func (m *KeyboardMonitor) readLoop(ctx context.Context, device *evdev.InputDevice) {
 	for {
 		select {
-		case <-m.ctx.Done():
+		case <-ctx.Done():
 			return
 		default:
 			// Read one event (blocking)
-			ev, err := m.device.ReadOne()
-			if err != nil {
-				if m.ctx.Err() != nil {
-					return // Context cancelled
-				}
-				m.logger.Error().Err(err).Msg("Keyboard read error")
+			ev, err := device.ReadOne()
+			if !m.handle(ctx, ev, err) {
 				return
 			}
+		}
+	}
+}
 
-			// We're looking for EV_KEY events with value=1 (key press)
-			// value=0 is key release, value=2 is key repeat
-			if ev.Type == evdev.EV_KEY && ev.Value == 1 {
-				m.logger.Debug().
-					Uint16("code", uint16(ev.Code)).
-					Msg("Key press detected")
+// handle handles the result of one read and reports whether to go on.
+// Once stopped nothing is handled, so no key is left held after Stop
+// released them; a failed read releases them itself.
+func (m *KeyboardMonitor) handle(ctx context.Context, ev *evdev.InputEvent, err error) bool {
+	m.handleMu.Lock()
+	defer m.handleMu.Unlock()
+	if ctx.Err() != nil {
+		return false // Stopped
+	}
+	if err != nil {
+		m.logger.Error().Err(err).Msg("Keyboard read error")
+		m.setReadErr(err)
+		m.releaseAll()
+		return false
+	}
+	if ev.Type == evdev.EV_KEY {
+		m.handleKey(ev.Code, KeyAction(ev.Value), time.Unix(ev.Time.Sec, ev.Time.Usec*1000))
+	}
+	return true
+}
 
-				if m.onKeyPress != nil {
-					m.onKeyPress()
-				}
-			}
+// handleKey reports a key event unless the key completes the chord or the
+// filter exempts it.
+func (m *KeyboardMonitor) handleKey(code evdev.EvCode, action KeyAction, at time.Time) {
+	m.mu.Lock()
+	reported, down := m.held[code]
+	switch action {
+	case KeyRelease:
+		delete(m.held, code)
+	case KeyPress:
+		m.held[code] = false
+	}
+	m.mu.Unlock()
+
+	if action != KeyPress {
+		// Repeats and releases only matter for keys reported as typing
+		if down && reported {
+			m.report(KeyEvent{Keyboard: m.devicePath, Code: code, Action: action, Time: at})
+		}
+		return
+	}
+
+	if m.chordComplete(code) {
+		m.logger.Info().Msg("Hotkey chord pressed")
+		m.onChord()
+		return
+	}
+	if m.filter != nil && m.filter.Exempt(code) {
+		if e := m.logger.Debug(); e.Enabled() {
+			e.Str("key_class", string(privacy.Classify(code))).Msg("Exempt key press ignored")
+		}
+		return
+	}
+
+	now := time.Now()
+	var sinceLast time.Duration
+	if !m.lastPress.IsZero() {
+		sinceLast = now.Sub(m.lastPress)
+	}
+	m.lastPress = now
+	if e := m.logger.Debug(); e.Enabled() {
+		m.keys.LogKey(e, code, sinceLast).Msg("Key press detected")
+	}
+
+	m.mu.Lock()
+	m.held[code] = true
+	m.mu.Unlock()
+	m.report(KeyEvent{Keyboard: m.devicePath, Code: code, Action: KeyPress, Time: at})
+}
+
+func (m *KeyboardMonitor) report(ev KeyEvent) {
+	if m.onKey != nil {
+		m.handlingSince.Store(time.Now().UnixNano())
+		m.onKey(ev)
+		m.handlingSince.Store(0)
+	}
+}
+
+// releaseAll reports the release of every key still held once the
+// keyboard is closed, so nobody waits for a release that cannot come.
+func (m *KeyboardMonitor) releaseAll() {
+	m.mu.Lock()
+	var codes []evdev.EvCode
+	for code, reported := range m.held {
+		if reported {
+			codes = append(codes, code)
+		}
+	}
+	clear(m.held)
+	m.mu.Unlock()
+	now := time.Now()
+	for _, code := range codes {
+		m.report(KeyEvent{Keyboard: m.devicePath, Code: code, Action: KeyRelease, Time: now})
+	}
+}
+
+// chordComplete reports whether pressing code completed the chord.
+func (m *KeyboardMonitor) chordComplete(code evdev.EvCode) bool {
+	if len(m.chord) == 0 || m.onChord == nil {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	last := false
+	for _, c := range m.chord {
+		if _, down := m.held[c]; !down {
+			return false
+		}
+		last = last || c == code
+	}
+	return last
+}
+
+// Responsive reports an error when the key press callback has been blocked
+// for longer than keyHandlerTimeout, i.e. the daemon stopped handling
+// typing. It does not depend on the keyboard: an idle, detached or failed
+// keyboard is responsive, see Status for its read error.
+func (m *KeyboardMonitor) Responsive() error {
+	if since := m.handlingSince.Load(); since != 0 {
+		if d := time.Since(time.Unix(0, since)); d > keyHandlerTimeout {
+			return fmt.Errorf("key press handler blocked for %s", d.Round(time.Second))
 		}
 	}
+	return nil
+}
+
+// KeyboardStatus is reported by the control interface.
+type KeyboardStatus struct {
+	Device string `json:"device"`
+	// Error is why the keyboard is not being read, e.g. it was detached
+	Error string `json:"error,omitempty"`
+}
+
+// Status returns the keyboard and why reading it stopped, if it did.
+func (m *KeyboardMonitor) Status() KeyboardStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := KeyboardStatus{Device: m.devicePath}
+	if m.readErr != nil {
+		s.Error = m.readErr.Error()
+	}
+	return s
+}
+
+func (m *KeyboardMonitor) setReadErr(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.readErr = err
 }
 
 // DevicePath returns the keyboard device path.
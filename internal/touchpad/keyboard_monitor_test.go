@@ -0,0 +1,112 @@
+package touchpad
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyboardMonitor_Responsive(t *testing.T) {
+	m := NewKeyboardMonitor("/nonexistent/event3", nil, zerolog.Nop())
+	assert.NoError(t, m.Responsive(), "an idle monitor is responsive")
+
+	// Key press handler still running, but not for long
+	m.handlingSince.Store(time.Now().UnixNano())
+	assert.NoError(t, m.Responsive())
+
+	m.handlingSince.Store(time.Now().Add(-2 * keyHandlerTimeout).UnixNano())
+	assert.ErrorContains(t, m.Responsive(), "key press handler blocked")
+	m.handlingSince.Store(0)
+
+	// A keyboard that went away is reported, but the daemon is still alive
+	m.setReadErr(errors.New("no such device"))
+	assert.NoError(t, m.Responsive())
+	assert.Equal(t, KeyboardStatus{Device: "/nonexistent/event3", Error: "no such device"}, m.Status())
+
+	require.NoError(t, m.Stop())
+	assert.Empty(t, m.Status().Error, "a stopped monitor has no error")
+	require.Error(t, m.Start(context.Background()))
+	assert.Contains(t, m.Status().Error, "failed to open keyboard device", "until a restart fails")
+	assert.NoError(t, m.Responsive())
+}
+
+type exemptKeys map[evdev.EvCode]bool
+
+func (e exemptKeys) Exempt(code evdev.EvCode) bool { return e[code] }
+
+func TestKeyboardMonitor_HandleKey(t *testing.T) {
+	var got []KeyEvent
+	chords := 0
+	m := NewKeyboardMonitor("/dev/input/event3", func(ev KeyEvent) { got = append(got, ev) }, zerolog.Nop())
+	m.SetKeyFilter(exemptKeys{evdev.KEY_W: true})
+	m.SetChord([]evdev.EvCode{evdev.KEY_LEFTCTRL, evdev.KEY_LEFTALT, evdev.KEY_G}, func() { chords++ })
+	at := time.Unix(1700000000, 0)
+	actions := func() []string {
+		var s []string
+		for _, ev := range got {
+			s = append(s, evdev.CodeName(evdev.EV_KEY, ev.Code)+" "+ev.Action.String())
+		}
+		got = nil
+		return s
+	}
+
+	m.handleKey(evdev.KEY_W, KeyPress, at)
+	m.handleKey(evdev.KEY_W, KeyRepeat, at)
+	m.handleKey(evdev.KEY_W, KeyRelease, at)
+	assert.Empty(t, actions(), "exempt keys are not typing")
+
+	m.handleKey(evdev.KEY_BACKSPACE, KeyPress, at)
+	assert.Equal(t, KeyEvent{Keyboard: "/dev/input/event3", Code: evdev.KEY_BACKSPACE, Action: KeyPress, Time: at}, got[0])
+	m.handleKey(evdev.KEY_BACKSPACE, KeyRepeat, at)
+	m.handleKey(evdev.KEY_BACKSPACE, KeyRelease, at)
+	assert.Equal(t, []string{"KEY_BACKSPACE press", "KEY_BACKSPACE repeat", "KEY_BACKSPACE release"}, actions())
+
+	m.handleKey(evdev.KEY_LEFTCTRL, KeyPress, at)
+	m.handleKey(evdev.KEY_LEFTALT, KeyPress, at)
+	m.handleKey(evdev.KEY_G, KeyPress, at)
+	assert.Equal(t, 1, chords)
+	m.handleKey(evdev.KEY_G, KeyRepeat, at)
+	m.handleKey(evdev.KEY_LEFTALT, KeyRelease, at)
+	m.handleKey(evdev.KEY_G, KeyRelease, at)
+	assert.Equal(t, []string{"KEY_LEFTCTRL press", "KEY_LEFTALT press", "KEY_LEFTALT release"}, actions(),
+		"the modifiers count, the key completing the chord does not")
+	m.handleKey(evdev.KEY_G, KeyPress, at)
+	assert.Equal(t, 1, chords, "the chord needs every key held")
+	assert.Equal(t, []string{"KEY_G press"}, actions())
+
+	// Closing the keyboard releases what is still held
+	require.NoError(t, m.Stop())
+	assert.ElementsMatch(t, []string{"KEY_LEFTCTRL release", "KEY_G release"}, actions())
+}
+
+func TestKeyboardMonitor_Handle(t *testing.T) {
+	var got []KeyAction
+	m := NewKeyboardMonitor("/dev/input/event3", func(ev KeyEvent) { got = append(got, ev.Action) }, zerolog.Nop())
+	ctx, cancel := context.WithCancel(context.Background())
+	press := &evdev.InputEvent{Type: evdev.EV_KEY, Code: evdev.KEY_A, Value: int32(KeyPress)}
+
+	assert.True(t, m.handle(ctx, press, nil))
+	assert.False(t, m.handle(ctx, nil, errors.New("no such device")))
+	assert.Equal(t, []KeyAction{KeyPress, KeyRelease}, got, "a failed read releases the held keys")
+	assert.Equal(t, "no such device", m.Status().Error)
+
+	got = nil
+	cancel()
+	assert.False(t, m.handle(ctx, press, nil))
+	assert.Empty(t, got, "nothing is handled once stopped")
+}
+
+func TestParseKeys(t *testing.T) {
+	codes, err := ParseKeys([]string{"KEY_W", "a", " space "})
+	require.NoError(t, err)
+	assert.Equal(t, []evdev.EvCode{evdev.KEY_W, evdev.KEY_A, evdev.KEY_SPACE}, codes)
+
+	_, err = ParseKeys([]string{"KEY_W", "hyper"})
+	assert.EqualError(t, err, `unknown key "hyper"`)
+}
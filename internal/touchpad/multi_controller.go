@@ -5,6 +5,8 @@ import (
 	"sync"
 
 	"github.com/rs/zerolog"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/metrics"
 )
 
 // MultiController manages multiple touchpad controllers.
@@ -12,19 +14,40 @@ import (
 // This controller ensures all touchpads are grabbed/ungrabbed together.
 type MultiController struct {
 	controllers []*Controller
+	// active limits Disable/Enable to these device paths; nil means all.
+	active map[string]bool
+	// groups maps a keyboard path to the touchpads on the same physical device
+	groups       map[string][]string
+	deviceGroups []*DeviceGroup
+	metrics      *metrics.Metrics
+	intent       IntentCriteria
+	onIntent     func(path, reason string)
+	// suppression is keyed by device name or path; names maps the paths
+	// of the controlled touchpads to their names
+	suppression map[string]Suppression
+	taps        TapCriteria
+	names       map[string]string
 	mu          sync.Mutex
 	logger      zerolog.Logger
+	// base is the logger without this component, for controllers added
+	// by AddDevice
+	base zerolog.Logger
 }
 
 // NewMultiController creates a new multi-touchpad controller.
 func NewMultiController(devices []*DeviceInfo, logger zerolog.Logger) *MultiController {
 	controllers := make([]*Controller, 0, len(devices))
+	names := make(map[string]string, len(devices))
 	for _, dev := range devices {
 		controllers = append(controllers, NewController(dev.Path, logger))
+		names[dev.Path] = dev.Name
 	}
 	return &MultiController{
 		controllers: controllers,
+		names:       names,
+		taps:        DefaultTapCriteria,
 		logger:      logger.With().Str("component", "multi_touchpad_ctrl").Logger(),
+		base:        logger,
 	}
 }
 
@@ -64,12 +87,61 @@ func (m *MultiController) Close() error {
 	return lastErr
 }
 
-// Disable disables all touchpads by grabbing them.
+// SetActive limits palm rejection to the touchpads with the given paths;
+// nil restores all of them. Touchpads leaving the set are released.
+func (m *MultiController) SetActive(paths []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if paths == nil {
+		m.active = nil
+	} else {
+		m.active = make(map[string]bool, len(paths))
+		for _, p := range paths {
+			m.active[p] = true
+		}
+	}
+
+	for _, ctrl := range m.controllers {
+		if !m.isActive(ctrl) && ctrl.IsDisabled() {
+			if err := ctrl.Enable(); err != nil {
+				m.logger.Warn().Err(err).Str("device", ctrl.DevicePath()).Msg("Failed to release inactive touchpad")
+			}
+		}
+	}
+	m.logger.Info().Strs("active", m.activePaths()).Msg("Active touchpads changed")
+}
+
+// ActivePaths returns the touchpads palm rejection currently applies to.
+func (m *MultiController) ActivePaths() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.activePaths()
+}
+
+func (m *MultiController) activePaths() []string {
+	paths := []string{}
+	for _, ctrl := range m.controllers {
+		if m.isActive(ctrl) {
+			paths = append(paths, ctrl.DevicePath())
+		}
+	}
+	return paths
+}
+
+func (m *MultiController) isActive(ctrl *Controller) bool {
+	return m.active == nil || m.active[ctrl.DevicePath()]
+}
+
+// Disable disables all active touchpads by grabbing them.
 func (m *MultiController) Disable() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	for _, ctrl := range m.controllers {
+		if !m.isActive(ctrl) {
+			continue
+		}
 		if err := ctrl.Disable(); err != nil {
 			return fmt.Errorf("failed to disable touchpad: %w", err)
 		}
@@ -77,7 +149,67 @@ func (m *MultiController) Disable() error {
 	return nil
 }
 
-// Enable enables all touchpads by releasing the grab.
+// SetGroups records which touchpads belong to which keyboard, for DisableFor.
+func (m *MultiController) SetGroups(groups []*DeviceGroup) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.deviceGroups = groups
+	m.groups = make(map[string][]string)
+	for _, g := range groups {
+		for _, kb := range g.Keyboards {
+			m.groups[kb] = append(m.groups[kb], g.Touchpads...)
+		}
+	}
+}
+
+// Groups returns the device groups last passed to SetGroups.
+func (m *MultiController) Groups() []*DeviceGroup {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.deviceGroups
+}
+
+// DisableFor disables the active touchpads on the same physical device as
+// keyboard. If the keyboard has no touchpad of its own (e.g. keyd's
+// virtual keyboard or the laptop's built-in keyboard) all active
+// touchpads are disabled.
+func (m *MultiController) DisableFor(keyboard string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, ctrl := range m.targetsFor(keyboard) {
+		if err := ctrl.Disable(); err != nil {
+			return fmt.Errorf("failed to disable touchpad: %w", err)
+		}
+	}
+	return nil
+}
+
+// targetsFor returns the active controllers typing on keyboard suppresses.
+func (m *MultiController) targetsFor(keyboard string) []*Controller {
+	own := make(map[string]bool)
+	for _, path := range m.groups[keyboard] {
+		own[path] = true
+	}
+	var mine, all []*Controller
+	for _, ctrl := range m.controllers {
+		if !m.isActive(ctrl) {
+			continue
+		}
+		all = append(all, ctrl)
+		if own[ctrl.DevicePath()] {
+			mine = append(mine, ctrl)
+		}
+	}
+	if len(mine) > 0 {
+		return mine
+	}
+	return all
+}
+
+// Enable enables all touchpads by releasing the grab. Inactive touchpads
+// are never grabbed, so releasing them is a no-op.
 func (m *MultiController) Enable() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -90,17 +222,123 @@ func (m *MultiController) Enable() error {
 	return nil
 }
 
-// IsDisabled returns whether ALL touchpads are currently disabled.
+// AddDevice opens a touchpad that appeared after startup (e.g. a Bluetooth
+// keyboard's touchpad) and adds it to the controlled set.
+func (m *MultiController) AddDevice(dev *DeviceInfo) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, ctrl := range m.controllers {
+		if ctrl.DevicePath() == dev.Path {
+			return nil
+		}
+	}
+	ctrl := NewController(dev.Path, m.base)
+	ctrl.SetMetrics(m.metrics)
+	if m.onIntent != nil {
+		ctrl.SetOnIntent(m.intent, m.onIntent)
+	}
+	m.names[dev.Path] = dev.Name
+	ctrl.SetSuppression(m.suppressionFor(dev.Path), m.taps)
+	if err := ctrl.Open(); err != nil {
+		return fmt.Errorf("failed to open touchpad: %w", err)
+	}
+	m.controllers = append(m.controllers, ctrl)
+	m.logger.Info().Str("device", dev.Path).Str("name", dev.Name).Msg("Touchpad added")
+	return nil
+}
+
+// RemoveDevice closes a touchpad that went away and forgets it.
+func (m *MultiController) RemoveDevice(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, ctrl := range m.controllers {
+		if ctrl.DevicePath() != path {
+			continue
+		}
+		// The device is usually gone already, so the ungrab may fail
+		if err := ctrl.Close(); err != nil {
+			m.logger.Debug().Err(err).Str("device", path).Msg("Close of removed touchpad failed")
+		}
+		m.controllers = append(m.controllers[:i], m.controllers[i+1:]...)
+		m.logger.Info().Str("device", path).Msg("Touchpad removed")
+		return
+	}
+}
+
+// Has reports whether the touchpad at path is controlled.
+func (m *MultiController) Has(path string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, ctrl := range m.controllers {
+		if ctrl.DevicePath() == path {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMetrics enables instrumentation on every touchpad controller.
+func (m *MultiController) SetMetrics(mt *metrics.Metrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics = mt
+	for _, ctrl := range m.controllers {
+		ctrl.SetMetrics(mt)
+	}
+}
+
+// SetOnIntent reports deliberate touches on any suppressed touchpad,
+// including ones added later, to fn. Call before Open.
+func (m *MultiController) SetOnIntent(criteria IntentCriteria, fn func(path, reason string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.intent, m.onIntent = criteria, fn
+	for _, ctrl := range m.controllers {
+		ctrl.SetOnIntent(criteria, fn)
+	}
+}
+
+// SetSuppression selects per touchpad, by device name or path, what it
+// still lets through while disabled, and what a tap is for SuppressTaps.
+// The entry "*" applies to touchpads not listed, including ones added
+// later; without it they are grabbed completely. Call before Open.
+func (m *MultiController) SetSuppression(modes map[string]Suppression, taps TapCriteria) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.suppression, m.taps = modes, taps
+	for _, ctrl := range m.controllers {
+		ctrl.SetSuppression(m.suppressionFor(ctrl.DevicePath()), taps)
+	}
+}
+
+// suppressionFor looks a touchpad up by path first, then by name.
+func (m *MultiController) suppressionFor(path string) Suppression {
+	for _, key := range []string{path, m.names[path], "*"} {
+		if s, ok := m.suppression[key]; ok {
+			return s
+		}
+	}
+	return SuppressGrab
+}
+
+// IsDisabled returns whether ALL active touchpads are currently disabled.
 func (m *MultiController) IsDisabled() bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	active := 0
 	for _, ctrl := range m.controllers {
+		if !m.isActive(ctrl) {
+			continue
+		}
+		active++
 		if !ctrl.IsDisabled() {
 			return false
 		}
 	}
-	return len(m.controllers) > 0
+	return active > 0
 }
 
 // Stop stops the controller and releases all touchpads.
@@ -110,5 +348,7 @@ func (m *MultiController) Stop() error {
 
 // DeviceCount returns the number of touchpad devices being controlled.
 func (m *MultiController) DeviceCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return len(m.controllers)
 }
@@ -0,0 +1,31 @@
+package touchpad
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	evdev "github.com/holoplot/go-evdev"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/privsep"
+)
+
+// openInputDevice opens an event node. Once the daemon has dropped root,
+// nodes it cannot open itself (e.g. a hot-plugged keyboard without the
+// input group's permissions) are opened by the privilege helper.
+func openInputDevice(path string, flag int) (*evdev.InputDevice, error) {
+	dev, err := evdev.OpenWithFlags(path, flag)
+	if err == nil || !errors.Is(err, fs.ErrPermission) {
+		return dev, err
+	}
+	f, err := privsep.OpenFile(path, flag, 0)
+	if err != nil {
+		return nil, err
+	}
+	dev, err = evdev.NewFromFile(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s is not an input device: %w", path, err)
+	}
+	return dev, nil
+}
@@ -0,0 +1,125 @@
+package touchpad
+
+import (
+	"fmt"
+	"slices"
+
+	evdev "github.com/holoplot/go-evdev"
+)
+
+// Suppression selects what a suppressed touchpad still lets through.
+type Suppression string
+
+const (
+	// SuppressGrab lets nothing through.
+	SuppressGrab Suppression = "grab"
+	// SuppressClicks drops motion and taps but passes physical button
+	// clicks on through a virtual mouse.
+	SuppressClicks Suppression = "clicks"
+	// SuppressTaps drops only short contacts (see TapCriteria) and passes
+	// everything else on through a virtual copy of the touchpad.
+	SuppressTaps Suppression = "taps"
+)
+
+// Suppressions lists the valid suppression modes.
+var Suppressions = []Suppression{SuppressGrab, SuppressClicks, SuppressTaps}
+
+// ParseSuppression validates a suppression mode; "" is SuppressGrab.
+func ParseSuppression(s string) (Suppression, error) {
+	if s == "" {
+		return SuppressGrab, nil
+	}
+	if slices.Contains(Suppressions, Suppression(s)) {
+		return Suppression(s), nil
+	}
+	return "", fmt.Errorf("unknown suppression %q (have: grab, clicks, taps)", s)
+}
+
+// clickButtons are the buttons SuppressClicks passes on.
+var clickButtons = []evdev.EvCode{evdev.BTN_LEFT, evdev.BTN_RIGHT, evdev.BTN_MIDDLE}
+
+// eventWriter is the virtual device events are passed on to.
+type eventWriter interface {
+	WriteOne(event *evdev.InputEvent) error
+}
+
+// forwarder passes on what a suppression lets through.
+type forwarder interface {
+	// handle is fed every event of the touchpad; only while grabbed is
+	// anything passed on.
+	handle(ev *evdev.InputEvent, grabbed bool) error
+	// release lets go of whatever is still down on the virtual device,
+	// for when the grab ends.
+	release() error
+}
+
+// newVirtualMouse creates the device SuppressClicks writes to. It needs
+// relative axes to be taken for a mouse, but never moves.
+func newVirtualMouse(touchpadName string) (*virtualDevice, error) {
+	return createVirtualDevice(virtualSpec{
+		name: virtualPrefix + "clicks: " + touchpadName,
+		id:   evdev.InputID{BusType: evdev.BUS_VIRTUAL},
+		codes: map[evdev.EvType][]evdev.EvCode{
+			evdev.EV_KEY: clickButtons,
+			evdev.EV_REL: {evdev.REL_X, evdev.REL_Y},
+		},
+	})
+}
+
+// clickForwarder passes the button events of a grabbed touchpad on to a
+// virtual mouse and drops everything else.
+type clickForwarder struct {
+	out eventWriter
+	// pressed holds the buttons pressed on out, so a release is only
+	// passed on for a press that was
+	pressed map[evdev.EvCode]bool
+	// frame is set when the current frame passed something on
+	frame bool
+}
+
+func newClickForwarder(out eventWriter) *clickForwarder {
+	return &clickForwarder{out: out, pressed: make(map[evdev.EvCode]bool)}
+}
+
+// handle implements forwarder.
+func (f *clickForwarder) handle(ev *evdev.InputEvent, grabbed bool) error {
+	switch {
+	case !grabbed:
+		return nil
+	case ev.Type == evdev.EV_KEY && slices.Contains(clickButtons, ev.Code):
+		switch {
+		case ev.Value == 1:
+			f.pressed[ev.Code] = true
+		case ev.Value == 0 && f.pressed[ev.Code]:
+			delete(f.pressed, ev.Code)
+		default:
+			return nil // autorepeat, or the press went to the touchpad
+		}
+		f.frame = true
+		return f.write(evdev.EV_KEY, ev.Code, ev.Value)
+	case ev.Type == evdev.EV_SYN && ev.Code == evdev.SYN_REPORT && f.frame:
+		f.frame = false
+		return f.write(evdev.EV_SYN, evdev.SYN_REPORT, 0)
+	}
+	return nil
+}
+
+// release implements forwarder: a button held when the grab ends is
+// released on the touchpad itself.
+func (f *clickForwarder) release() error {
+	if len(f.pressed) == 0 {
+		return nil
+	}
+	for code := range f.pressed {
+		if err := f.write(evdev.EV_KEY, code, 0); err != nil {
+			return err
+		}
+		delete(f.pressed, code)
+	}
+	f.frame = false
+	return f.write(evdev.EV_SYN, evdev.SYN_REPORT, 0)
+}
+
+func (f *clickForwarder) write(t evdev.EvType, code evdev.EvCode, value int32) error {
+	return f.out.WriteOne(&evdev.InputEvent{Type: t, Code: code, Value: value})
+}
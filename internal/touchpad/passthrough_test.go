@@ -0,0 +1,93 @@
+package touchpad
+
+import (
+	"testing"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWriter struct {
+	events []evdev.InputEvent
+}
+
+func (w *fakeWriter) WriteOne(ev *evdev.InputEvent) error {
+	w.events = append(w.events, *ev)
+	return nil
+}
+
+func TestClickForwarder(t *testing.T) {
+	out := &fakeWriter{}
+	f := newClickForwarder(out)
+	feed := func(events ...evdev.InputEvent) {
+		for i := range events {
+			require.NoError(t, f.handle(&events[i], true))
+		}
+	}
+	syn := evdev.InputEvent{Type: evdev.EV_SYN, Code: evdev.SYN_REPORT}
+
+	// A palm: touch and motion only
+	feed(key(evdev.BTN_TOUCH, 1), abs(evdev.ABS_X, 100), syn, abs(evdev.ABS_X, 300), syn, key(evdev.BTN_TOUCH, 0), syn)
+	assert.Empty(t, out.events, "motion and touches are dropped")
+
+	// A click: the button and its frame are passed on, the touch is not
+	feed(key(evdev.BTN_TOUCH, 1), key(evdev.BTN_LEFT, 1), abs(evdev.ABS_X, 100), syn)
+	feed(key(evdev.BTN_LEFT, 0), syn, key(evdev.BTN_TOUCH, 0), syn)
+	assert.Equal(t, []evdev.InputEvent{key(evdev.BTN_LEFT, 1), syn, key(evdev.BTN_LEFT, 0), syn}, out.events)
+
+	// A release whose press went to the touchpad before the grab
+	out.events = nil
+	feed(key(evdev.BTN_RIGHT, 0), syn)
+	assert.Empty(t, out.events)
+
+	// A button still down when the grab ends is let go
+	feed(key(evdev.BTN_RIGHT, 1), syn)
+	out.events = nil
+	require.NoError(t, f.release())
+	assert.Equal(t, []evdev.InputEvent{key(evdev.BTN_RIGHT, 0), syn}, out.events)
+	out.events = nil
+	require.NoError(t, f.release())
+	assert.Empty(t, out.events, "nothing left to release")
+}
+
+func TestParseSuppression(t *testing.T) {
+	s, err := ParseSuppression("")
+	require.NoError(t, err)
+	assert.Equal(t, SuppressGrab, s)
+
+	s, err = ParseSuppression("clicks")
+	require.NoError(t, err)
+	assert.Equal(t, SuppressClicks, s)
+
+	s, err = ParseSuppression("taps")
+	require.NoError(t, err)
+	assert.Equal(t, SuppressTaps, s)
+
+	_, err = ParseSuppression("taps-and-clicks")
+	assert.ErrorContains(t, err, `unknown suppression "taps-and-clicks"`)
+}
+
+func TestMultiController_Suppression(t *testing.T) {
+	m := NewMultiController([]*DeviceInfo{
+		{Path: "/dev/input/event5", Name: "ELAN1200:00 Touchpad"},
+		{Path: "/dev/input/event7", Name: "ASUS Zenbook Duo Keyboard Touchpad"},
+	}, zerolog.Nop())
+	m.SetSuppression(map[string]Suppression{
+		"ASUS Zenbook Duo Keyboard Touchpad": SuppressClicks,
+		"/dev/input/event5":                  SuppressGrab,
+	}, DefaultTapCriteria)
+
+	assert.Equal(t, SuppressGrab, m.suppressionFor("/dev/input/event5"))
+	assert.Equal(t, SuppressClicks, m.suppressionFor("/dev/input/event7"), "matched by name")
+	assert.Equal(t, SuppressClicks, m.controllers[1].suppression)
+	assert.Equal(t, SuppressGrab, m.suppressionFor("/dev/input/event9"), "unknown touchpads are grabbed")
+
+	taps := TapCriteria{Time: 150 * time.Millisecond, MoveMM: 2}
+	m.SetSuppression(map[string]Suppression{"*": SuppressTaps, "/dev/input/event5": SuppressGrab}, taps)
+	assert.Equal(t, SuppressGrab, m.suppressionFor("/dev/input/event5"))
+	assert.Equal(t, SuppressTaps, m.suppressionFor("/dev/input/event9"), `"*" applies to the rest`)
+	assert.Equal(t, taps, m.controllers[1].taps)
+}
@@ -0,0 +1,151 @@
+package touchpad
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	evdev "github.com/holoplot/go-evdev"
+)
+
+// sysfsWordBits is the width of one word in sysfs capability bitmaps
+// (the kernel's unsigned long on 64-bit systems).
+const sysfsWordBits = 64
+
+// InputID identifies the hardware behind an input device, as found in
+// /sys/class/input/eventX/device/id.
+type InputID struct {
+	BusType uint16
+	Vendor  uint16
+	Product uint16
+	Version uint16
+}
+
+// Bus returns the lower-case bus name, e.g. "usb", "bluetooth" or "i8042".
+func (id InputID) Bus() string {
+	name, ok := evdev.BUSToString[evdev.EvCode(id.BusType)]
+	if !ok {
+		return fmt.Sprintf("0x%02x", id.BusType)
+	}
+	return strings.ToLower(strings.TrimPrefix(name, "BUS_"))
+}
+
+// String formats the ID as "bus vendor:product".
+func (id InputID) String() string {
+	return fmt.Sprintf("%s %04x:%04x", id.Bus(), id.Vendor, id.Product)
+}
+
+// MarshalJSON writes the IDs as hex strings, the form used in udev rules.
+func (id InputID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Bus     string `json:"bus"`
+		Vendor  string `json:"vendor"`
+		Product string `json:"product"`
+		Version string `json:"version"`
+	}{
+		Bus:     id.Bus(),
+		Vendor:  fmt.Sprintf("%04x", id.Vendor),
+		Product: fmt.Sprintf("%04x", id.Product),
+		Version: fmt.Sprintf("%04x", id.Version),
+	})
+}
+
+// readInputID reads the bus, vendor, product and version of an event node.
+func readInputID(eventName string) InputID {
+	read := func(field string) uint16 {
+		data, err := os.ReadFile(filepath.Join(sysClassInput, eventName, "device", "id", field))
+		if err != nil {
+			return 0
+		}
+		v, err := strconv.ParseUint(strings.TrimSpace(string(data)), 16, 16)
+		if err != nil {
+			return 0
+		}
+		return uint16(v)
+	}
+	return InputID{
+		BusType: read("bustype"),
+		Vendor:  read("vendor"),
+		Product: read("product"),
+		Version: read("version"),
+	}
+}
+
+// readHIDParent resolves the device an input device hangs off, e.g.
+// /sys/devices/.../0005:0B05:1B2D.0003, or "" if it cannot be resolved.
+func readHIDParent(eventName string) string {
+	parent, err := filepath.EvalSymlinks(filepath.Join(sysClassInput, eventName, "device", "device"))
+	if err != nil {
+		return ""
+	}
+	return parent
+}
+
+// readCapabilities returns the event type names (EV_KEY, EV_ABS, ...) and
+// input properties (INPUT_PROP_BUTTONPAD, ...) advertised in sysfs.
+func readCapabilities(eventName string) (types []string, props []string) {
+	dir := filepath.Join(sysClassInput, eventName, "device")
+	for _, bit := range readBitmap(filepath.Join(dir, "capabilities", "ev")) {
+		if name, ok := evdev.EVToString[evdev.EvType(bit)]; ok {
+			types = append(types, name)
+		}
+	}
+	for _, bit := range readBitmap(filepath.Join(dir, "properties")) {
+		if name, ok := evdev.INPUTToString[evdev.EvProp(bit)]; ok {
+			props = append(props, name)
+		}
+	}
+	return types, props
+}
+
+func readBitmap(path string) []int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return parseBitmap(string(data))
+}
+
+// parseBitmap decodes a sysfs bitmap: space separated hex words, most
+// significant word first.
+func parseBitmap(s string) []int {
+	words := strings.Fields(s)
+	var bits []int
+	for i, word := range words {
+		v, err := strconv.ParseUint(word, 16, 64)
+		if err != nil {
+			return nil
+		}
+		base := (len(words) - 1 - i) * sysfsWordBits
+		for b := 0; b < sysfsWordBits; b++ {
+			if v&(1<<uint(b)) != 0 {
+				bits = append(bits, base+b)
+			}
+		}
+	}
+	sort.Ints(bits)
+	return bits
+}
+
+// usbInterface matches sysfs USB interface names such as "3-5:1.0".
+var usbInterface = regexp.MustCompile(`^\d+-[\d.]+:\d+\.\d+$`)
+
+// physicalDevice returns the sysfs path identifying the physical device a
+// HID device belongs to. Composite USB devices expose the keyboard and the
+// touchpad on separate interfaces, so those are grouped by the USB device;
+// anything else (Bluetooth, I2C) by the HID device itself.
+func physicalDevice(hidParent string) string {
+	if hidParent == "" {
+		return ""
+	}
+	parent := filepath.Dir(hidParent)
+	if usbInterface.MatchString(filepath.Base(parent)) {
+		return filepath.Dir(parent)
+	}
+	return hidParent
+}
@@ -0,0 +1,235 @@
+package touchpad
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+)
+
+// TapCriteria decide which contacts SuppressTaps drops: a contact lifted
+// within Time that travelled less than MoveMM millimetres is a tap.
+type TapCriteria struct {
+	Time   time.Duration
+	MoveMM float64
+}
+
+// DefaultTapCriteria are a little more generous than libinput's own tap
+// detection, so whatever libinput would take for a tap is dropped.
+var DefaultTapCriteria = TapCriteria{Time: 200 * time.Millisecond, MoveMM: 3}
+
+// newVirtualTouchpad creates the copy of dev SuppressTaps writes to.
+func newVirtualTouchpad(touchpadName string, dev *evdev.InputDevice) (*virtualDevice, error) {
+	spec, err := specOf(virtualPrefix+"taps: "+touchpadName, dev)
+	if err != nil {
+		return nil, err
+	}
+	return createVirtualDevice(spec)
+}
+
+type tapPhase int
+
+const (
+	// tapIdle is between contacts
+	tapIdle tapPhase = iota
+	// tapPending holds a contact back until it is known not to be a tap
+	tapPending
+	// tapPassing passes a contact that is not a tap on as it happens
+	tapPassing
+	// tapStale drops a contact that began before the grab
+	tapStale
+)
+
+// slotEvent is an event with the multitouch slot it applies to.
+type slotEvent struct {
+	evdev.InputEvent
+	slot int32
+}
+
+// tapFilter passes everything of a grabbed touchpad on to a virtual copy,
+// except for taps. Events are grouped in frames, each ending with a
+// SYN_REPORT, and a contact's frames are held back until it moves or stays
+// down long enough not to be a tap; then they are passed on frame by frame,
+// so the contact still starts where the finger landed. The kernel stamps
+// events written to uinput with the time of the write, so the held frames
+// arrive late and at once: the pointer catches up in one go, by less than
+// the criteria's MoveMM since the contact is let through as soon as it
+// moves that far.
+type tapFilter struct {
+	out        eventWriter
+	criteria   TapCriteria
+	unitsPerMM float64
+	// multitouch is set for touchpads with slots (protocol B)
+	multitouch bool
+
+	// The touchpad's state
+	slot     int32
+	touching bool
+	x, y     int32
+
+	// The virtual device's state: the selected slot, the slots with a
+	// contact and the keys that are down
+	outSlot int32
+	active  map[int32]bool
+	down    map[evdev.EvCode]bool
+
+	phase          tapPhase
+	start          time.Time
+	startX, startY int32
+	frame          []slotEvent
+	held           []slotEvent
+}
+
+// newTapFilter creates a filter for a touchpad with the given axes.
+func newTapFilter(out eventWriter, criteria TapCriteria, abs map[evdev.EvCode]evdev.AbsInfo) *tapFilter {
+	slots, multitouch := abs[evdev.ABS_MT_SLOT]
+	return &tapFilter{
+		out:        out,
+		criteria:   criteria,
+		unitsPerMM: unitsPerMM(abs[evdev.ABS_X]),
+		multitouch: multitouch,
+		slot:       slots.Value,
+		active:     make(map[int32]bool),
+		down:       make(map[evdev.EvCode]bool),
+	}
+}
+
+// handle implements forwarder. It follows the touchpad's state even while
+// not grabbed, so a grab that starts in the middle of a contact is known.
+func (f *tapFilter) handle(ev *evdev.InputEvent, grabbed bool) error {
+	switch {
+	case ev.Type == evdev.EV_ABS && ev.Code == evdev.ABS_MT_SLOT:
+		// Kept with every event instead; see write
+		f.slot = ev.Value
+		return nil
+	case ev.Type == evdev.EV_KEY && ev.Code == evdev.BTN_TOUCH:
+		f.touching = ev.Value != 0
+	case ev.Type == evdev.EV_ABS && ev.Code == evdev.ABS_X:
+		f.x = ev.Value
+	case ev.Type == evdev.EV_ABS && ev.Code == evdev.ABS_Y:
+		f.y = ev.Value
+	}
+	f.frame = append(f.frame, slotEvent{InputEvent: *ev, slot: f.slot})
+	if ev.Type != evdev.EV_SYN || ev.Code != evdev.SYN_REPORT {
+		return nil
+	}
+	at := time.Unix(ev.Time.Sec, ev.Time.Usec*1000)
+	err := f.endFrame(f.frame, at, grabbed)
+	f.frame = f.frame[:0]
+	return err
+}
+
+func (f *tapFilter) endFrame(frame []slotEvent, at time.Time, grabbed bool) error {
+	if !grabbed {
+		f.phase = f.between()
+		return nil
+	}
+	switch f.phase {
+	case tapStale:
+		if !f.touching {
+			f.phase = tapIdle
+		}
+		return nil
+	case tapPassing:
+		if !f.touching {
+			f.phase = tapIdle
+		}
+		return f.write(frame)
+	case tapIdle:
+		if !f.touching {
+			return f.write(frame) // e.g. a button on a touchpad with separate ones
+		}
+		f.phase, f.start, f.startX, f.startY = tapPending, at, f.x, f.y
+	}
+
+	f.held = append(f.held, frame...)
+	if at.Sub(f.start) < f.criteria.Time && f.moved() < f.criteria.MoveMM && !clicked(frame) {
+		if !f.touching {
+			f.phase, f.held = tapIdle, f.held[:0] // a tap
+		}
+		return nil
+	}
+	f.phase = tapPassing
+	if !f.touching {
+		f.phase = tapIdle
+	}
+	err := f.write(f.held)
+	f.held = f.held[:0]
+	return err
+}
+
+// between is the phase while not grabbed: a contact in progress then goes
+// to the touchpad itself and is left alone.
+func (f *tapFilter) between() tapPhase {
+	if f.touching {
+		return tapStale
+	}
+	return tapIdle
+}
+
+// moved is how far the contact has travelled, in millimetres.
+func (f *tapFilter) moved() float64 {
+	return math.Hypot(float64(f.x-f.startX), float64(f.y-f.startY)) / f.unitsPerMM
+}
+
+// clicked reports whether frame presses a physical button, which is never
+// a tap.
+func clicked(frame []slotEvent) bool {
+	for _, ev := range frame {
+		if ev.Type == evdev.EV_KEY && ev.Value == 1 && slices.Contains(clickButtons, ev.Code) {
+			return true
+		}
+	}
+	return false
+}
+
+// write passes events on. Slot changes are not passed on as they come but
+// wherever the virtual device's slot differs from the event's: frames that
+// were dropped may have changed the touchpad's slot.
+func (f *tapFilter) write(events []slotEvent) error {
+	for i := range events {
+		ev := &events[i].InputEvent
+		if f.multitouch && ev.Type == evdev.EV_ABS && ev.Code > evdev.ABS_MT_SLOT && ev.Code <= evdev.ABS_MT_TOOL_Y && events[i].slot != f.outSlot {
+			f.outSlot = events[i].slot
+			slot := evdev.InputEvent{Time: ev.Time, Type: evdev.EV_ABS, Code: evdev.ABS_MT_SLOT, Value: f.outSlot}
+			if err := f.out.WriteOne(&slot); err != nil {
+				return err
+			}
+		}
+		switch {
+		case ev.Type == evdev.EV_ABS && ev.Code == evdev.ABS_MT_TRACKING_ID && ev.Value == -1:
+			delete(f.active, f.outSlot)
+		case ev.Type == evdev.EV_ABS && ev.Code == evdev.ABS_MT_TRACKING_ID:
+			f.active[f.outSlot] = true
+		case ev.Type == evdev.EV_KEY && ev.Value == 0:
+			delete(f.down, ev.Code)
+		case ev.Type == evdev.EV_KEY:
+			f.down[ev.Code] = true
+		}
+		if err := f.out.WriteOne(ev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// release implements forwarder: every contact and key still down on the
+// virtual device is lifted, as the rest of them goes to the touchpad
+// itself.
+func (f *tapFilter) release() error {
+	f.phase, f.held = f.between(), f.held[:0]
+	var lift []slotEvent
+	for _, slot := range slices.Sorted(maps.Keys(f.active)) {
+		lift = append(lift, slotEvent{InputEvent: evdev.InputEvent{Type: evdev.EV_ABS, Code: evdev.ABS_MT_TRACKING_ID, Value: -1}, slot: slot})
+	}
+	for _, code := range slices.Sorted(maps.Keys(f.down)) {
+		lift = append(lift, slotEvent{InputEvent: evdev.InputEvent{Type: evdev.EV_KEY, Code: code}})
+	}
+	if len(lift) == 0 {
+		return nil
+	}
+	lift = append(lift, slotEvent{InputEvent: evdev.InputEvent{Type: evdev.EV_SYN, Code: evdev.SYN_REPORT}})
+	return f.write(lift)
+}
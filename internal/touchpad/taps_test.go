@@ -0,0 +1,166 @@
+package touchpad
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	evdev "github.com/holoplot/go-evdev"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tapTouchpad feeds a tap filter frames of a 10 units/mm multitouch
+// touchpad and records what is passed on.
+type tapTouchpad struct {
+	t   *testing.T
+	out *fakeWriter
+	f   *tapFilter
+}
+
+func newTapTouchpad(t *testing.T) *tapTouchpad {
+	out := &fakeWriter{}
+	abs := map[evdev.EvCode]evdev.AbsInfo{
+		evdev.ABS_X:       {Maximum: 1000, Resolution: 10},
+		evdev.ABS_MT_SLOT: {Maximum: 4},
+	}
+	return &tapTouchpad{t: t, out: out, f: newTapFilter(out, DefaultTapCriteria, abs)}
+}
+
+// frame feeds events at the given time since the start, followed by a
+// SYN_REPORT.
+func (p *tapTouchpad) frame(at time.Duration, grabbed bool, events ...evdev.InputEvent) {
+	p.t.Helper()
+	tv := syscall.NsecToTimeval(int64(time.Second + at))
+	for _, ev := range append(events, evdev.InputEvent{Type: evdev.EV_SYN, Code: evdev.SYN_REPORT}) {
+		ev.Time = tv
+		require.NoError(p.t, p.f.handle(&ev, grabbed))
+	}
+}
+
+func (p *tapTouchpad) down(at time.Duration, id, x int32) {
+	p.frame(at, true, abs(evdev.ABS_MT_TRACKING_ID, id), abs(evdev.ABS_MT_POSITION_X, x), abs(evdev.ABS_X, x), key(evdev.BTN_TOUCH, 1))
+}
+
+func (p *tapTouchpad) move(at time.Duration, x int32) {
+	p.frame(at, true, abs(evdev.ABS_MT_POSITION_X, x), abs(evdev.ABS_X, x))
+}
+
+func (p *tapTouchpad) up(at time.Duration) {
+	p.frame(at, true, abs(evdev.ABS_MT_TRACKING_ID, -1), key(evdev.BTN_TOUCH, 0))
+}
+
+// passed returns what was passed on, without timestamps and SYN_REPORTs.
+func (p *tapTouchpad) passed() []evdev.InputEvent {
+	var events []evdev.InputEvent
+	for _, ev := range p.out.events {
+		if ev.Type != evdev.EV_SYN {
+			ev.Time = syscall.Timeval{}
+			events = append(events, ev)
+		}
+	}
+	p.out.events = nil
+	return events
+}
+
+func key(code evdev.EvCode, value int32) evdev.InputEvent {
+	return evdev.InputEvent{Type: evdev.EV_KEY, Code: code, Value: value}
+}
+
+func abs(code evdev.EvCode, value int32) evdev.InputEvent {
+	return evdev.InputEvent{Type: evdev.EV_ABS, Code: code, Value: value}
+}
+
+func TestTapFilter_DropsTaps(t *testing.T) {
+	p := newTapTouchpad(t)
+	p.down(0, 1, 100)
+	p.move(50*time.Millisecond, 110) // 1mm
+	p.up(120 * time.Millisecond)
+	assert.Empty(t, p.passed(), "a short contact that hardly moved is a tap")
+
+	p.down(time.Second, 2, 100)
+	p.frame(time.Second+100*time.Millisecond, true, abs(evdev.ABS_MT_SLOT, 1), abs(evdev.ABS_MT_TRACKING_ID, 3), key(evdev.BTN_TOOL_DOUBLETAP, 1))
+	p.frame(time.Second+150*time.Millisecond, true, abs(evdev.ABS_MT_TRACKING_ID, -1), key(evdev.BTN_TOOL_DOUBLETAP, 0))
+	p.frame(time.Second+160*time.Millisecond, true, abs(evdev.ABS_MT_SLOT, 0))
+	p.up(time.Second + 170*time.Millisecond)
+	assert.Empty(t, p.passed(), "so is a two-finger tap")
+}
+
+func TestTapFilter_PassesMotion(t *testing.T) {
+	p := newTapTouchpad(t)
+	p.down(0, 1, 100)
+	p.move(20*time.Millisecond, 115)
+	assert.Empty(t, p.passed(), "held back while it may still be a tap")
+
+	p.move(40*time.Millisecond, 140) // 4mm
+	assert.Equal(t, []evdev.InputEvent{
+		abs(evdev.ABS_MT_TRACKING_ID, 1), abs(evdev.ABS_MT_POSITION_X, 100), abs(evdev.ABS_X, 100), key(evdev.BTN_TOUCH, 1),
+		abs(evdev.ABS_MT_POSITION_X, 115), abs(evdev.ABS_X, 115),
+		abs(evdev.ABS_MT_POSITION_X, 140), abs(evdev.ABS_X, 140),
+	}, p.passed(), "the whole contact is passed on once it moves")
+
+	p.move(60*time.Millisecond, 150)
+	p.up(80 * time.Millisecond)
+	assert.Equal(t, []evdev.InputEvent{
+		abs(evdev.ABS_MT_POSITION_X, 150), abs(evdev.ABS_X, 150),
+		abs(evdev.ABS_MT_TRACKING_ID, -1), key(evdev.BTN_TOUCH, 0),
+	}, p.passed(), "and the rest as it happens")
+}
+
+func TestTapFilter_KeepsFrames(t *testing.T) {
+	p := newTapTouchpad(t)
+	p.down(0, 1, 100)
+	p.move(20*time.Millisecond, 110)
+	p.move(40*time.Millisecond, 140)
+
+	syn := evdev.InputEvent{Type: evdev.EV_SYN, Code: evdev.SYN_REPORT}
+	var frames [][]evdev.InputEvent
+	var frame []evdev.InputEvent
+	for _, ev := range p.out.events {
+		ev.Time = syscall.Timeval{}
+		frame = append(frame, ev)
+		if ev == syn {
+			frames = append(frames, frame)
+			frame = nil
+		}
+	}
+	assert.Empty(t, frame, "nothing after the last SYN_REPORT")
+	assert.Equal(t, [][]evdev.InputEvent{
+		{abs(evdev.ABS_MT_TRACKING_ID, 1), abs(evdev.ABS_MT_POSITION_X, 100), abs(evdev.ABS_X, 100), key(evdev.BTN_TOUCH, 1), syn},
+		{abs(evdev.ABS_MT_POSITION_X, 110), abs(evdev.ABS_X, 110), syn},
+		{abs(evdev.ABS_MT_POSITION_X, 140), abs(evdev.ABS_X, 140), syn},
+	}, frames, "held frames are passed on one by one, not merged")
+}
+
+func TestTapFilter_Slots(t *testing.T) {
+	p := newTapTouchpad(t)
+	// A tap in slot 1 is dropped, slot change and all
+	p.frame(0, true, abs(evdev.ABS_MT_SLOT, 1), abs(evdev.ABS_MT_TRACKING_ID, 1), abs(evdev.ABS_X, 100), key(evdev.BTN_TOUCH, 1))
+	p.up(50 * time.Millisecond)
+	require.Empty(t, p.passed())
+
+	// so the next contact in slot 1 has to select it on the virtual device
+	p.down(time.Second, 2, 100)
+	p.move(time.Second+250*time.Millisecond, 100)
+	assert.Equal(t, []evdev.InputEvent{
+		abs(evdev.ABS_MT_SLOT, 1), abs(evdev.ABS_MT_TRACKING_ID, 2), abs(evdev.ABS_MT_POSITION_X, 100), abs(evdev.ABS_X, 100), key(evdev.BTN_TOUCH, 1),
+		abs(evdev.ABS_MT_POSITION_X, 100), abs(evdev.ABS_X, 100),
+	}, p.passed(), "a contact resting longer than a tap is passed on")
+
+	require.NoError(t, p.f.release())
+	assert.Equal(t, []evdev.InputEvent{abs(evdev.ABS_MT_TRACKING_ID, -1), key(evdev.BTN_TOUCH, 0)}, p.passed(), "the end of the grab lifts it")
+	require.NoError(t, p.f.release())
+	assert.Empty(t, p.passed(), "nothing left to lift")
+}
+
+func TestTapFilter_ContactBeforeGrab(t *testing.T) {
+	p := newTapTouchpad(t)
+	p.frame(0, false, abs(evdev.ABS_MT_TRACKING_ID, 1), abs(evdev.ABS_X, 100), key(evdev.BTN_TOUCH, 1))
+	p.move(time.Second, 500)
+	p.up(2 * time.Second)
+	assert.Empty(t, p.passed(), "a contact that began before the grab went to the touchpad")
+
+	p.down(3*time.Second, 2, 100)
+	p.frame(3*time.Second+10*time.Millisecond, true, key(evdev.BTN_LEFT, 1))
+	assert.Contains(t, p.passed(), key(evdev.BTN_LEFT, 1), "a click is no tap")
+}
@@ -0,0 +1,164 @@
+package touchpad
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+
+	evdev "github.com/holoplot/go-evdev"
+
+	"github.com/artonio/zenbook-duo-palm-rejection/internal/privsep"
+)
+
+// uinput ioctls, from linux/uinput.h.
+const (
+	uiDevCreate  = 0x5501
+	uiDevDestroy = 0x5502
+	uiSetEvBit   = 0x40045564
+	uiSetKeyBit  = 0x40045565
+	uiSetRelBit  = 0x40045566
+	uiSetAbsBit  = 0x40045567
+	uiSetMscBit  = 0x40045568
+	uiSetPropBit = 0x4004556e
+)
+
+// uiDevSetup and uiAbsSetup are _IOW('U', 3|4, struct) for the structs
+// below.
+var (
+	uiDevSetup = ioctlWrite(3, unsafe.Sizeof(uinputSetup{}))
+	uiAbsSetup = ioctlWrite(4, unsafe.Sizeof(uinputAbsSetup{}))
+)
+
+type uinputSetup struct {
+	ID         evdev.InputID
+	Name       [80]byte
+	EffectsMax uint32
+}
+
+type uinputAbsSetup struct {
+	Code uint16
+	_    uint16
+	Info evdev.AbsInfo
+}
+
+func ioctlWrite(nr, size uintptr) uintptr {
+	return 1<<30 | size<<16 | 'U'<<8 | nr
+}
+
+// virtualSpec describes a virtual device. go-evdev can create and clone
+// devices too, but it sets neither axis ranges nor properties, without
+// which a virtual touchpad is not taken for one.
+type virtualSpec struct {
+	name  string
+	id    evdev.InputID
+	codes map[evdev.EvType][]evdev.EvCode
+	abs   map[evdev.EvCode]evdev.AbsInfo
+	props []evdev.EvProp
+}
+
+// specOf describes a copy of dev named name.
+func specOf(name string, dev *evdev.InputDevice) (virtualSpec, error) {
+	id, err := dev.InputID()
+	if err != nil {
+		return virtualSpec{}, err
+	}
+	abs, err := dev.AbsInfos()
+	if err != nil {
+		return virtualSpec{}, err
+	}
+	spec := virtualSpec{name: name, id: id, codes: make(map[evdev.EvType][]evdev.EvCode), abs: abs, props: dev.Properties()}
+	for _, t := range dev.CapableTypes() {
+		spec.codes[t] = dev.CapableEvents(t)
+	}
+	return spec, nil
+}
+
+// virtualDevice is a device created through /dev/uinput.
+type virtualDevice struct {
+	file *os.File
+}
+
+func createVirtualDevice(spec virtualSpec) (*virtualDevice, error) {
+	// Once root is dropped the privilege helper opens it
+	f, err := privsep.OpenFile("/dev/uinput", os.O_WRONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := setupVirtualDevice(f, spec); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to create %q: %w", spec.name, err)
+	}
+	return &virtualDevice{file: f}, nil
+}
+
+func setupVirtualDevice(f *os.File, spec virtualSpec) error {
+	bits := map[evdev.EvType]uintptr{
+		evdev.EV_KEY: uiSetKeyBit,
+		evdev.EV_REL: uiSetRelBit,
+		evdev.EV_ABS: uiSetAbsBit,
+		evdev.EV_MSC: uiSetMscBit,
+	}
+	for t, codes := range spec.codes {
+		set, ok := bits[t]
+		if !ok {
+			continue // LEDs, sounds and force feedback are not passed on
+		}
+		if err := ioctl(f, uiSetEvBit, uintptr(t)); err != nil {
+			return err
+		}
+		for _, code := range codes {
+			if err := ioctl(f, set, uintptr(code)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, p := range spec.props {
+		if err := ioctl(f, uiSetPropBit, uintptr(p)); err != nil {
+			return err
+		}
+	}
+	for code, info := range spec.abs {
+		abs := uinputAbsSetup{Code: uint16(code), Info: info}
+		if err := ioctlPtr(f, uiAbsSetup, unsafe.Pointer(&abs)); err != nil {
+			return err
+		}
+	}
+	setup := uinputSetup{ID: spec.id}
+	copy(setup.Name[:len(setup.Name)-1], spec.name)
+	if err := ioctlPtr(f, uiDevSetup, unsafe.Pointer(&setup)); err != nil {
+		return err
+	}
+	return ioctl(f, uiDevCreate, 0)
+}
+
+func ioctl(f *os.File, req, arg uintptr) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), req, arg); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func ioctlPtr(f *os.File, req uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), req, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// WriteOne implements eventWriter.
+func (d *virtualDevice) WriteOne(ev *evdev.InputEvent) error {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.NativeEndian, ev)
+	_, err := d.file.Write(buf.Bytes())
+	return err
+}
+
+// Close removes the device.
+func (d *virtualDevice) Close() error {
+	err := ioctl(d.file, uiDevDestroy, 0)
+	d.file.Close()
+	return err
+}
@@ -0,0 +1,176 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultSyslogIdentifier is the SYSLOG_IDENTIFIER of journal entries, so
+// "journalctl -t palm-reject-daemon" finds them.
+const DefaultSyslogIdentifier = "palm-reject-daemon"
+
+// journalSocket is journald's native protocol socket; replaced in tests.
+var journalSocket = "/run/systemd/journal/socket"
+
+// JournalWriter sends zerolog's JSON lines to journald using the native
+// protocol, so fields stay fields: the message becomes MESSAGE, the level
+// PRIORITY, "component" and "device" become COMPONENT and DEVICE, and any
+// other field is kept under its upper-cased name. This makes
+// "journalctl COMPONENT=kb_monitor" or "journalctl -p warning" work.
+type JournalWriter struct {
+	identifier string
+
+	mu   sync.Mutex
+	conn *net.UnixConn
+}
+
+// NewJournalWriter connects to the journal socket.
+func NewJournalWriter(identifier string) (*JournalWriter, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journalSocket, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to journald: %w", err)
+	}
+	return &JournalWriter{identifier: identifier, conn: conn}, nil
+}
+
+// Write sends one JSON log line, taking the priority from its level field.
+func (w *JournalWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel sends one JSON log line at the given level.
+func (w *JournalWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	fields := make(map[string]any)
+	dec := json.NewDecoder(bytes.NewReader(p))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		// Not JSON; log it as is
+		fields = map[string]any{zerolog.MessageFieldName: strings.TrimSpace(string(p))}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.conn.Write(encodeJournal(fields, level, w.identifier)); err != nil {
+		return 0, fmt.Errorf("failed to write to journald: %w", err)
+	}
+	return len(p), nil
+}
+
+// Close closes the journal socket.
+func (w *JournalWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.conn.Close()
+}
+
+// encodeJournal serialises a log entry in the journal native format.
+func encodeJournal(fields map[string]any, level zerolog.Level, identifier string) []byte {
+	if level == zerolog.NoLevel {
+		if s, ok := fields[zerolog.LevelFieldName].(string); ok {
+			level, _ = zerolog.ParseLevel(s)
+		}
+	}
+
+	message, _ := fields[zerolog.MessageFieldName].(string)
+	if errMsg, ok := fields[zerolog.ErrorFieldName].(string); ok {
+		if message == "" {
+			message = errMsg
+		} else {
+			message += ": " + errMsg
+		}
+	}
+
+	var buf bytes.Buffer
+	writeJournalField(&buf, "MESSAGE", message)
+	writeJournalField(&buf, "PRIORITY", fmt.Sprint(priority(level)))
+	writeJournalField(&buf, "SYSLOG_IDENTIFIER", identifier)
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		switch k {
+		case zerolog.MessageFieldName, zerolog.LevelFieldName, zerolog.TimestampFieldName:
+			// MESSAGE, PRIORITY and journald's own timestamp
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writeJournalField(&buf, journalFieldName(k), journalValue(fields[k]))
+	}
+	return buf.Bytes()
+}
+
+// writeJournalField appends KEY=value, or the length-prefixed binary form
+// for values containing newlines.
+func writeJournalField(buf *bytes.Buffer, key, value string) {
+	if !strings.Contains(value, "\n") {
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	buf.WriteString(key)
+	buf.WriteByte('\n')
+	binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journalFieldName upper-cases a zerolog field name into a valid journal
+// field name: [A-Z0-9_], not starting with an underscore or digit (those
+// are reserved for trusted fields) and at most 64 characters.
+func journalFieldName(key string) string {
+	name := []byte(strings.ToUpper(key))
+	for i, c := range name {
+		if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			name[i] = '_'
+		}
+	}
+	if len(name) == 0 || name[0] == '_' || name[0] >= '0' && name[0] <= '9' {
+		name = append([]byte("F_"), name...)
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return string(name)
+}
+
+func journalValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// priority maps a zerolog level to a syslog priority.
+func priority(level zerolog.Level) int {
+	switch level {
+	case zerolog.PanicLevel:
+		return 0 // emerg
+	case zerolog.FatalLevel:
+		return 2 // crit
+	case zerolog.ErrorLevel:
+		return 3 // err
+	case zerolog.WarnLevel:
+		return 4 // warning
+	case zerolog.InfoLevel, zerolog.NoLevel:
+		return 6 // info
+	default:
+		return 7 // debug
+	}
+}
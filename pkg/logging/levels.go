@@ -0,0 +1,199 @@
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// Levels holds the default log level and per-component overrides, e.g.
+// "info,kb_monitor=warn,typing_detection=debug". The component is the
+// "component" field every daemon component adds to its logger. Levels can
+// be changed while the daemon runs.
+type Levels struct {
+	mu        sync.RWMutex
+	def       zerolog.Level
+	overrides map[string]zerolog.Level
+	// global is set once the levels own zerolog's global level
+	global bool
+}
+
+// ParseLevels parses a level spec: comma separated entries that are either
+// a bare level (the default) or component=level.
+func ParseLevels(spec string) (*Levels, error) {
+	l := &Levels{def: zerolog.InfoLevel, overrides: make(map[string]zerolog.Level)}
+	if err := l.apply(spec, l.overrides); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Update applies a level spec on top of the current levels. A bare level
+// replaces the default, component=level sets an override and
+// component=default removes it.
+func (l *Levels) Update(spec string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	overrides := make(map[string]zerolog.Level, len(l.overrides))
+	for k, v := range l.overrides {
+		overrides[k] = v
+	}
+	def := l.def
+	if err := l.apply(spec, overrides); err != nil {
+		l.def = def
+		return err
+	}
+	l.overrides = overrides
+	l.updateGlobal()
+	return nil
+}
+
+// Enabled reports whether a message from component at level is logged.
+func (l *Levels) Enabled(component string, level zerolog.Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	min, ok := l.overrides[component]
+	if !ok {
+		min = l.def
+	}
+	return level >= min
+}
+
+// String returns the current levels in spec form, overrides sorted.
+func (l *Levels) String() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	parts := []string{l.def.String()}
+	components := make([]string, 0, len(l.overrides))
+	for c := range l.overrides {
+		components = append(components, c)
+	}
+	sort.Strings(components)
+	for _, c := range components {
+		parts = append(parts, c+"="+l.overrides[c].String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// Min returns the most verbose level any component logs at.
+func (l *Levels) Min() zerolog.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.minLocked()
+}
+
+// SetGlobal keeps zerolog's global level at Min from now on, so messages
+// no component wants are dropped before they are formatted. It affects
+// every logger in the process; only the process's own levels should.
+func (l *Levels) SetGlobal() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.global = true
+	l.updateGlobal()
+}
+
+// Filter wraps w so that it only receives the lines Enabled lets through.
+func (l *Levels) Filter(w io.Writer) zerolog.LevelWriter {
+	return &levelFilter{levels: l, w: zerolog.MultiLevelWriter(w)}
+}
+
+// apply parses spec into l.def and overrides; the caller holds the lock or
+// owns l.
+func (l *Levels) apply(spec string, overrides map[string]zerolog.Level) error {
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		component, name, found := strings.Cut(entry, "=")
+		if !found {
+			level, err := parseLevelStrict(entry)
+			if err != nil {
+				return err
+			}
+			l.def = level
+			continue
+		}
+		component = strings.TrimSpace(component)
+		name = strings.TrimSpace(name)
+		if component == "" {
+			return fmt.Errorf("missing component in %q", entry)
+		}
+		if name == "default" {
+			delete(overrides, component)
+			continue
+		}
+		level, err := parseLevelStrict(name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", component, err)
+		}
+		overrides[component] = level
+	}
+	return nil
+}
+
+func (l *Levels) minLocked() zerolog.Level {
+	min := l.def
+	for _, level := range l.overrides {
+		if level < min {
+			min = level
+		}
+	}
+	return min
+}
+
+// updateGlobal lets zerolog drop messages no component wants before they
+// are formatted, once SetGlobal was called.
+func (l *Levels) updateGlobal() {
+	if l.global {
+		zerolog.SetGlobalLevel(l.minLocked())
+	}
+}
+
+func parseLevelStrict(name string) (zerolog.Level, error) {
+	switch strings.ToLower(name) {
+	case "trace", "debug", "info", "warn", "warning", "error", "fatal":
+		return ParseLevel(name), nil
+	}
+	return zerolog.NoLevel, fmt.Errorf("unknown log level %q", name)
+}
+
+// levelFilter drops lines whose component is not enabled at their level.
+type levelFilter struct {
+	levels *Levels
+	w      zerolog.LevelWriter
+}
+
+var componentKey = []byte(`"component":"`)
+
+func (f *levelFilter) Write(p []byte) (int, error) {
+	return f.w.Write(p)
+}
+
+func (f *levelFilter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if !f.levels.Enabled(lineComponent(p), level) {
+		return len(p), nil
+	}
+	return f.w.WriteLevel(level, p)
+}
+
+// lineComponent extracts the component field from a JSON log line without
+// decoding the whole line.
+func lineComponent(p []byte) string {
+	i := bytes.Index(p, componentKey)
+	if i < 0 {
+		return ""
+	}
+	rest := p[i+len(componentKey):]
+	end := bytes.IndexByte(rest, '"')
+	if end < 0 {
+		return ""
+	}
+	return string(rest[:end])
+}
@@ -1,6 +1,8 @@
 package logging
 
 import (
+    "fmt"
+    "io"
     "os"
     "strings"
     "time"
@@ -8,63 +10,135 @@ import (
     "github.com/rs/zerolog"
 )
 
-// SetupLogger creates and configures a logger based on the log level.
-func SetupLogger(level string) zerolog.Logger {
-    var logLevel zerolog.Level
-    switch strings.ToLower(level) {
-    case "trace":
-        logLevel = zerolog.TraceLevel
-    case "debug":
-        logLevel = zerolog.DebugLevel
-    case "info":
-        logLevel = zerolog.InfoLevel
-    case "warn", "warning":
-        logLevel = zerolog.WarnLevel
-    case "error":
-        logLevel = zerolog.ErrorLevel
-    case "fatal":
-        logLevel = zerolog.FatalLevel
+// Backends selectable with Options.Backend.
+const (
+    // BackendAuto uses journald when stderr is connected to the journal
+    // (i.e. when run by systemd) and the console otherwise.
+    BackendAuto     = "auto"
+    BackendConsole  = "console"
+    BackendJSON     = "json"
+    BackendJournald = "journald"
+)
+
+// Backends lists the valid Options.Backend values.
+var Backends = []string{BackendAuto, BackendConsole, BackendJSON, BackendJournald}
+
+// Options selects where and how the daemon logs.
+type Options struct {
+    // Level is the minimum level (trace, debug, info, warn, error, fatal),
+    // optionally with per-component overrides (see ParseLevels).
+    Level string
+    // Backend is one of Backends; empty means BackendAuto.
+    Backend string
+    // File, if set, receives a JSON copy of every log line.
+    File string
+    // FileMaxSize is the size in bytes at which File is rotated; 0 uses
+    // DefaultMaxSize.
+    FileMaxSize int64
+    // FileMaxBackups is how many rotated files are kept.
+    FileMaxBackups int
+}
+
+// Output is a configured logger and what it writes to.
+type Output struct {
+    Logger zerolog.Logger
+    // Levels can be changed at runtime and take effect on Logger and every
+    // logger derived from it.
+    Levels *Levels
+    closer multiCloser
+    file   *RotatingFile
+}
+
+// Close releases the log file and journal socket.
+func (o *Output) Close() error {
+    return o.closer.Close()
+}
+
+// Chown hands the log file to uid and gid before the daemon drops root;
+// see RotatingFile.Chown. It does nothing without a log file.
+func (o *Output) Chown(uid, gid int) error {
+    if o.file == nil {
+        return nil
+    }
+    return o.file.Chown(uid, gid)
+}
+
+// New creates a logger from opts. The returned Output must be closed on
+// shutdown.
+func New(opts Options) (*Output, error) {
+    levels, err := ParseLevels(opts.Level)
+    if err != nil {
+        return nil, err
+    }
+
+    var writers []io.Writer
+    var closers multiCloser
+
+    switch backend := resolveBackend(opts.Backend); backend {
+    case BackendConsole:
+        writers = append(writers, consoleWriter())
+    case BackendJSON:
+        writers = append(writers, os.Stderr)
+    case BackendJournald:
+        journal, err := NewJournalWriter(DefaultSyslogIdentifier)
+        if err != nil {
+            return nil, err
+        }
+        writers = append(writers, journal)
+        closers = append(closers, journal)
     default:
-        logLevel = zerolog.InfoLevel
+        return nil, fmt.Errorf("unknown log backend %q (want one of %s)", opts.Backend, strings.Join(Backends, ", "))
     }
 
-    output := zerolog.ConsoleWriter{
-        Out:        os.Stderr,
-        TimeFormat: time.RFC3339,
+    var file *RotatingFile
+    if opts.File != "" {
+        file, err = OpenRotatingFile(opts.File, opts.FileMaxSize, opts.FileMaxBackups)
+        if err != nil {
+            closers.Close()
+            return nil, err
+        }
+        writers = append(writers, file)
+        closers = append(closers, file)
     }
 
-    return zerolog.New(output).
-        Level(logLevel).
+    // Every level reaches the filter, which decides per component; see
+    // Levels.SetGlobal for dropping the rest before they are formatted
+    logger := zerolog.New(levels.Filter(zerolog.MultiLevelWriter(writers...))).
+        Level(zerolog.TraceLevel).
         With().
         Timestamp().
         Logger()
+    return &Output{Logger: logger, Levels: levels, closer: closers, file: file}, nil
+}
+
+// SetupLogger creates and configures a logger based on the log level.
+func SetupLogger(level string) zerolog.Logger {
+    return newLogger(consoleWriter(), level)
 }
 
 // SetupLoggerJSON creates a logger that outputs JSON.
 func SetupLoggerJSON(level string) zerolog.Logger {
-    var logLevel zerolog.Level
+    return newLogger(os.Stderr, level)
+}
+
+// ParseLevel maps a level name to a zerolog level; unknown names are info.
+func ParseLevel(level string) zerolog.Level {
     switch strings.ToLower(level) {
     case "trace":
-        logLevel = zerolog.TraceLevel
+        return zerolog.TraceLevel
     case "debug":
-        logLevel = zerolog.DebugLevel
+        return zerolog.DebugLevel
     case "info":
-        logLevel = zerolog.InfoLevel
+        return zerolog.InfoLevel
     case "warn", "warning":
-        logLevel = zerolog.WarnLevel
+        return zerolog.WarnLevel
     case "error":
-        logLevel = zerolog.ErrorLevel
+        return zerolog.ErrorLevel
     case "fatal":
-        logLevel = zerolog.FatalLevel
+        return zerolog.FatalLevel
     default:
-        logLevel = zerolog.InfoLevel
+        return zerolog.InfoLevel
     }
-
-    return zerolog.New(os.Stderr).
-        Level(logLevel).
-        With().
-        Timestamp().
-        Logger()
 }
 
 // GetLogLevelFromEnv reads the LOG_LEVEL env var.
@@ -75,3 +149,44 @@ func GetLogLevelFromEnv() string {
     }
     return level
 }
+
+func newLogger(w io.Writer, level string) zerolog.Logger {
+    return zerolog.New(w).
+        Level(ParseLevel(level)).
+        With().
+        Timestamp().
+        Logger()
+}
+
+func consoleWriter() zerolog.ConsoleWriter {
+    return zerolog.ConsoleWriter{
+        Out:        os.Stderr,
+        TimeFormat: time.RFC3339,
+    }
+}
+
+// resolveBackend turns BackendAuto into a concrete backend.
+func resolveBackend(backend string) string {
+    if backend != "" && backend != BackendAuto {
+        return backend
+    }
+    // systemd sets JOURNAL_STREAM when stdout/stderr go to the journal
+    if os.Getenv("JOURNAL_STREAM") != "" {
+        if _, err := os.Stat(journalSocket); err == nil {
+            return BackendJournald
+        }
+    }
+    return BackendConsole
+}
+
+type multiCloser []io.Closer
+
+func (m multiCloser) Close() error {
+    var first error
+    for _, c := range m {
+        if err := c.Close(); err != nil && first == nil {
+            first = err
+        }
+    }
+    return first
+}
@@ -0,0 +1,228 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listenJournal(t *testing.T) *net.UnixConn {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	old := journalSocket
+	journalSocket = path
+	t.Cleanup(func() { journalSocket = old })
+	return conn
+}
+
+func readDatagram(t *testing.T, conn *net.UnixConn) string {
+	t.Helper()
+	buf := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	return string(buf[:n])
+}
+
+func TestJournalWriter(t *testing.T) {
+	conn := listenJournal(t)
+
+	w, err := NewJournalWriter(DefaultSyslogIdentifier)
+	require.NoError(t, err)
+	defer w.Close()
+
+	logger := zerolog.New(w).With().Timestamp().Str("component", "kb_monitor").Logger()
+	logger.Warn().
+		Str("device", "/dev/input/event3").
+		Int("touchpad_count", 2).
+		Err(assert.AnError).
+		Msg("Keyboard read error")
+
+	assert.Equal(t, "MESSAGE=Keyboard read error: "+assert.AnError.Error()+"\n"+
+		"PRIORITY=4\n"+
+		"SYSLOG_IDENTIFIER=palm-reject-daemon\n"+
+		"COMPONENT=kb_monitor\n"+
+		"DEVICE=/dev/input/event3\n"+
+		"ERROR="+assert.AnError.Error()+"\n"+
+		"TOUCHPAD_COUNT=2\n", readDatagram(t, conn))
+}
+
+func TestEncodeJournal_MultilineValue(t *testing.T) {
+	got := encodeJournal(map[string]any{"message": "two\nlines"}, zerolog.DebugLevel, "id")
+
+	var want bytes.Buffer
+	want.WriteString("MESSAGE\n")
+	binary.Write(&want, binary.LittleEndian, uint64(len("two\nlines")))
+	want.WriteString("two\nlines\nPRIORITY=7\nSYSLOG_IDENTIFIER=id\n")
+	assert.Equal(t, want.Bytes(), got)
+}
+
+func TestJournalFieldName(t *testing.T) {
+	assert.Equal(t, "COMPONENT", journalFieldName("component"))
+	assert.Equal(t, "KEY_CLASS", journalFieldName("key-class"))
+	assert.Equal(t, "F__PID", journalFieldName("_pid"))
+	assert.Equal(t, "F_2ND", journalFieldName("2nd"))
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "daemon.log")
+	f, err := OpenRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	defer f.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	read := func(p string) string {
+		data, err := os.ReadFile(p)
+		require.NoError(t, err)
+		return string(data)
+	}
+	assert.Equal(t, "fourth\n", read(path))
+	assert.Equal(t, "third\n", read(path+".1"))
+	assert.Equal(t, "second\n", read(path+".2"))
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestRotatingFile_RotateError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.log")
+	f, err := OpenRotatingFile(path, 10, 1)
+	require.NoError(t, err)
+	defer f.Close()
+
+	// A directory in the way of the backup makes the rotation fail
+	require.NoError(t, os.MkdirAll(filepath.Join(path+".1", "busy"), 0755))
+
+	_, err = f.Write([]byte("first\n"))
+	require.NoError(t, err)
+	n, err := f.Write([]byte("second\n"))
+	assert.ErrorContains(t, err, "failed to rotate log file")
+	assert.Equal(t, 7, n, "the line is written anyway")
+	_, err = f.Write([]byte("third\n"))
+	assert.NoError(t, err, "the failure is reported once")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\nthird\n", string(data))
+
+	require.NoError(t, os.RemoveAll(path+".1"))
+	_, err = f.Write([]byte("fourth\n"))
+	require.NoError(t, err)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "fourth\n", string(data))
+}
+
+func TestRotatingFile_Chown(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("needs root to chown")
+	}
+	root := t.TempDir()
+
+	path := filepath.Join(root, "logs", "daemon.log")
+	f, err := OpenRotatingFile(path, 10, 1)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, f.Chown(1000, 1000))
+	for _, p := range []string{path, filepath.Dir(path)} {
+		info, err := os.Stat(p)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1000), info.Sys().(*syscall.Stat_t).Uid, p)
+	}
+
+	// A directory that was there before is left alone
+	require.NoError(t, os.Chmod(root, 0755))
+	shared, err := OpenRotatingFile(filepath.Join(root, "shared.log"), 10, 1)
+	require.NoError(t, err)
+	defer shared.Close()
+	assert.ErrorContains(t, shared.Chown(1000, 1000), "cannot be rotated")
+}
+
+func TestNew(t *testing.T) {
+	global := zerolog.GlobalLevel()
+	path := filepath.Join(t.TempDir(), "daemon.log")
+	out, err := New(Options{Level: "warn", Backend: BackendJSON, File: path})
+	require.NoError(t, err)
+	assert.Equal(t, global, zerolog.GlobalLevel(), "the global level is left alone")
+
+	out.Logger.Info().Msg("hidden")
+	out.Logger.Warn().Str("component", "dock").Msg("shown")
+	require.NoError(t, out.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hidden")
+	assert.Contains(t, string(data), `"component":"dock"`)
+
+	_, err = New(Options{Backend: "syslog"})
+	assert.ErrorContains(t, err, `unknown log backend "syslog"`)
+}
+
+func TestLevels(t *testing.T) {
+	levels, err := ParseLevels("info, kb_monitor=warn,typing_detection=debug")
+	require.NoError(t, err)
+	assert.Equal(t, "info,kb_monitor=warn,typing_detection=debug", levels.String())
+	assert.Equal(t, zerolog.DebugLevel, levels.Min())
+
+	assert.False(t, levels.Enabled("kb_monitor", zerolog.InfoLevel))
+	assert.True(t, levels.Enabled("kb_monitor", zerolog.WarnLevel))
+	assert.True(t, levels.Enabled("typing_detection", zerolog.DebugLevel))
+	assert.False(t, levels.Enabled("dock", zerolog.DebugLevel))
+	assert.True(t, levels.Enabled("", zerolog.InfoLevel))
+
+	require.NoError(t, levels.Update("warning,kb_monitor=default,dock=trace"))
+	assert.Equal(t, "warn,dock=trace,typing_detection=debug", levels.String())
+
+	assert.ErrorContains(t, levels.Update("error,dock=loud"), `dock: unknown log level "loud"`)
+	assert.Equal(t, "warn,dock=trace,typing_detection=debug", levels.String(), "a failed update changes nothing")
+
+	_, err = ParseLevels("=debug")
+	assert.ErrorContains(t, err, "missing component")
+}
+
+func TestLevelsGlobal(t *testing.T) {
+	global := zerolog.GlobalLevel()
+	t.Cleanup(func() { zerolog.SetGlobalLevel(global) })
+
+	levels, err := ParseLevels("warn,dock=debug")
+	require.NoError(t, err)
+	require.NoError(t, levels.Update("dock=trace"))
+	assert.Equal(t, global, zerolog.GlobalLevel(), "not before SetGlobal")
+
+	levels.SetGlobal()
+	assert.Equal(t, zerolog.TraceLevel, zerolog.GlobalLevel())
+	require.NoError(t, levels.Update("dock=default"))
+	assert.Equal(t, zerolog.WarnLevel, zerolog.GlobalLevel())
+}
+
+func TestLevelsFilter(t *testing.T) {
+	levels, err := ParseLevels("info,kb_monitor=warn")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	logger := zerolog.New(levels.Filter(&buf))
+
+	logger.Info().Str("component", "kb_monitor").Msg("key press")
+	logger.Info().Str("component", "dock").Msg("docked")
+	assert.NotContains(t, buf.String(), "key press")
+	assert.Contains(t, buf.String(), "docked")
+
+	buf.Reset()
+	require.NoError(t, levels.Update("kb_monitor=info"))
+	logger.Info().Str("component", "kb_monitor").Msg("key press")
+	assert.Contains(t, buf.String(), "key press", "changes apply to existing loggers")
+}
@@ -0,0 +1,187 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+)
+
+// DefaultMaxSize is the size at which a log file is rotated by default.
+const DefaultMaxSize = 10 << 20
+
+// RotatingFile is a log file that is renamed to "<path>.1" once it reaches
+// its maximum size, shifting older files up to "<path>.<maxBackups>" and
+// deleting the oldest.
+type RotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	// createdDir is set when OpenRotatingFile created the log directory,
+	// which Chown then hands over
+	createdDir bool
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+	// rotateErr is the last rotation failure, reported by the first Write
+	// that hits it
+	rotateErr error
+}
+
+// OpenRotatingFile opens (appending to) or creates the log file at path.
+func OpenRotatingFile(path string, maxSize int64, maxBackups int) (*RotatingFile, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	r := &RotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if _, err := os.Stat(filepath.Dir(path)); errors.Is(err, fs.ErrNotExist) {
+		r.createdDir = true
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write appends p, rotating first if p would push the file past its
+// maximum size. A single line is never split across files. If rotation
+// fails, p is still written to the current file and the first Write after
+// the failure returns its error; later ones retry the rotation quietly.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	var rotateErr error
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		err := r.rotate()
+		if err != nil && r.rotateErr == nil {
+			rotateErr = err
+		}
+		r.rotateErr = err
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	if err == nil {
+		err = rotateErr
+	}
+	return n, err
+}
+
+// Chown hands the log file, its backups and, if OpenRotatingFile created
+// it, the log directory to uid and gid, so rotation keeps working after
+// the daemon drops root. It fails if the directory stays unwritable for
+// them, since rotation would then fail.
+func (r *RotatingFile) Chown(uid, gid int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.Chown(r.path, uid, gid); err != nil {
+		return fmt.Errorf("failed to chown log file: %w", err)
+	}
+	for i := 1; i <= r.maxBackups; i++ {
+		if err := os.Chown(r.backup(i), uid, gid); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to chown log file: %w", err)
+		}
+	}
+	dir := filepath.Dir(r.path)
+	if r.createdDir {
+		if err := os.Chown(dir, uid, gid); err != nil {
+			return fmt.Errorf("failed to chown log directory: %w", err)
+		}
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("failed to stat log directory: %w", err)
+	}
+	if !writableBy(info, uid, gid) {
+		return fmt.Errorf("log directory %s is not writable by uid %d, so %s cannot be rotated", dir, uid, r.path)
+	}
+	return nil
+}
+
+// writableBy reports whether files can be created in the directory by uid
+// and gid, going by its owner and mode. Supplementary groups and ACLs are
+// not considered.
+func writableBy(info fs.FileInfo, uid, gid int) bool {
+	perm := info.Mode().Perm()
+	st, ok := info.Sys().(*syscall.Stat_t)
+	switch {
+	case !ok:
+		return false
+	case int(st.Uid) == uid:
+		return perm&0300 == 0300
+	case int(st.Gid) == gid:
+		return perm&0030 == 0030
+	default:
+		return perm&0003 == 0003
+	}
+}
+
+// Close closes the file.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+func (r *RotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+// rotate starts a new file. The current one stays open until the new one
+// is, so a failure leaves logging to the current file.
+func (r *RotatingFile) rotate() error {
+	if r.maxBackups <= 0 {
+		// Nothing to keep: start over in place, which needs no directory access
+		if err := r.file.Truncate(0); err != nil {
+			return fmt.Errorf("failed to truncate log file: %w", err)
+		}
+		r.size = 0
+		return nil
+	}
+
+	os.Remove(r.backup(r.maxBackups))
+	for i := r.maxBackups - 1; i >= 1; i-- {
+		os.Rename(r.backup(i), r.backup(i+1))
+	}
+	if err := os.Rename(r.path, r.backup(1)); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	old := r.file
+	if err := r.open(); err != nil {
+		return err
+	}
+	old.Close()
+	return nil
+}
+
+func (r *RotatingFile) backup(n int) string {
+	return fmt.Sprintf("%s.%d", r.path, n)
+}
@@ -30,8 +30,10 @@ echo "Using Go: $GO_BIN"
 # Ensure bin directory exists
 mkdir -p bin
 
-# Build for current platform
-"$GO_BIN" build -ldflags="-s -w" -o bin/palm-reject-daemon ./cmd/palm-reject-daemon
+# Build for current platform. cgo is off so dropping root (run_as) can
+# set no_new_privs and capabilities on every thread; a cgo binary refuses
+# to drop root
+CGO_ENABLED=0 "$GO_BIN" build -ldflags="-s -w" -o bin/palm-reject-daemon ./cmd/palm-reject-daemon
 
 echo "Build complete: bin/palm-reject-daemon"
 
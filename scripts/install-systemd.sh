@@ -29,9 +29,18 @@ echo -e "${YELLOW}Installing binary...${NC}"
 cp bin/palm-reject-daemon /usr/local/bin/
 chmod 755 /usr/local/bin/palm-reject-daemon
 
+# Unprivileged user the daemon drops to after startup
+if ! id palm-reject &> /dev/null; then
+    echo -e "${YELLOW}Creating palm-reject user...${NC}"
+    useradd --system --no-create-home --shell /usr/sbin/nologin palm-reject
+fi
+
 # Copy systemd service file
 echo -e "${YELLOW}Installing systemd service...${NC}"
 cp scripts/palm-reject-daemon.service /etc/systemd/system/
+cp scripts/palm-reject-daemon.socket /etc/systemd/system/
+# Optional per-user variant (systemctl --user enable palm-reject-daemon-user)
+cp scripts/palm-reject-daemon-user.service /etc/systemd/user/
 
 # Reload systemd
 echo -e "${YELLOW}Reloading systemd...${NC}"
@@ -39,9 +48,10 @@ systemctl daemon-reload
 
 # Enable and start the service
 echo -e "${YELLOW}Enabling service...${NC}"
-systemctl enable palm-reject-daemon.service
+systemctl enable palm-reject-daemon.socket palm-reject-daemon.service
 
 echo -e "${YELLOW}Starting service...${NC}"
+systemctl start palm-reject-daemon.socket
 systemctl start palm-reject-daemon.service
 
 # Check status
@@ -17,15 +17,17 @@ fi
 
 # Stop the service if running
 echo -e "${YELLOW}Stopping service...${NC}"
-systemctl stop palm-reject-daemon.service 2>/dev/null || true
+systemctl stop palm-reject-daemon.service palm-reject-daemon.socket 2>/dev/null || true
 
 # Disable the service
 echo -e "${YELLOW}Disabling service...${NC}"
-systemctl disable palm-reject-daemon.service 2>/dev/null || true
+systemctl disable palm-reject-daemon.service palm-reject-daemon.socket 2>/dev/null || true
 
 # Remove systemd service file
 echo -e "${YELLOW}Removing systemd service...${NC}"
 rm -f /etc/systemd/system/palm-reject-daemon.service
+rm -f /etc/systemd/system/palm-reject-daemon.socket
+rm -f /etc/systemd/user/palm-reject-daemon-user.service
 
 # Reload systemd
 echo -e "${YELLOW}Reloading systemd...${NC}"
@@ -0,0 +1,3 @@
+module github.com/holoplot/go-evdev
+
+go 1.18